@@ -0,0 +1,181 @@
+package endure
+
+import (
+	"context"
+	"encoding/json"
+	stderr "errors"
+	"net"
+	"net/http"
+	// pprof endpoints are served by the admin server
+	"net/http/pprof"
+	"time"
+
+	"github.com/roadrunner-server/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAdminAddr    = "0.0.0.0:6061"
+	defaultAdminNetwork = "tcp"
+)
+
+// AdminConfig configures the admin server with the profiler (pprof) and the endure's debug endpoints:
+// /debug/endure/graph - dependency graph in the dot format, /debug/endure/plugins - plugins states, /debug/endure/state - container state,
+// /debug/endure/report - startup report
+type AdminConfig struct {
+	// Addr is the address to listen on (socket path for the unix network), 0.0.0.0:6061 by default
+	Addr string
+	// Network is the listener network: tcp (default), tcp4, tcp6 or unix
+	Network string
+	// Listener is the user's listener, Addr and Network are ignored when set
+	Listener net.Listener
+	// Mux is the user's mux, endure's endpoints are added to it, so the user's handlers are served by the same server
+	Mux *http.ServeMux
+	// Control enables the POST /debug/endure/plugins/{plugin}/stop and /debug/endure/plugins/{plugin}/restart endpoints
+	// the plugin is stopped (restarted) with its dependents
+	Control bool
+	// Probes adds the /health and /ready endpoints (see the HealthProbes) to the admin server
+	Probes bool
+	// Metrics adds the /metrics endpoint (see the MetricsServer) to the admin server
+	Metrics bool
+}
+
+// startServers starts the admin, the health probes and the metrics servers, the errors are returned from the Serve
+func (e *Endure) startServers() error {
+	var errs []error
+
+	var metricsHandler http.Handler
+	if e.metricsRegisterer != nil || e.metricsAddr != "" || (e.admin != nil && e.admin.Metrics) {
+		var err error
+		metricsHandler, err = e.exportMetrics()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if e.admin != nil {
+		mux := e.adminMux()
+		if e.admin.Probes {
+			e.handleProbes(mux)
+		}
+
+		if e.admin.Metrics && metricsHandler != nil {
+			mux.Handle("/metrics", metricsHandler)
+		}
+
+		network := e.admin.Network
+		if network == "" {
+			network = defaultAdminNetwork
+		}
+
+		addr := e.admin.Addr
+		if addr == "" {
+			addr = defaultAdminAddr
+		}
+
+		errs = append(errs, e.startServer("endure_admin_server", e.admin.Listener, network, addr, mux))
+	}
+
+	if e.probesAddr != "" {
+		mux := http.NewServeMux()
+		e.handleProbes(mux)
+		errs = append(errs, e.startServer("endure_health_probes", nil, "tcp", e.probesAddr, mux))
+	}
+
+	if e.metricsAddr != "" && metricsHandler != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsHandler)
+		errs = append(errs, e.startServer("endure_metrics_server", nil, "tcp", e.metricsAddr, mux))
+	}
+
+	return stderr.Join(errs...)
+}
+
+// startServer binds the listener (when the user's listener is nil) and serves the handler, the server is shut down by the stopServers
+func (e *Endure) startServer(op errors.Op, ln net.Listener, network, addr string, handler http.Handler) error {
+	if ln == nil {
+		var err error
+		ln, err = net.Listen(network, addr)
+		if err != nil {
+			return errors.E(op, errors.Network, err)
+		}
+	}
+
+	srv := &http.Server{
+		ReadHeaderTimeout: time.Minute * 5,
+		Handler:           handler,
+	}
+
+	e.servers = append(e.servers, srv)
+	e.log.Debug("server started", zap.String("server", string(op)), zap.String("addr", ln.Addr().String()))
+
+	go func() {
+		err := srv.Serve(ln)
+		if err != nil && err != http.ErrServerClosed {
+			e.log.Error("server error", zap.String("server", string(op)), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// adminMux returns the user's (or a new) mux with the pprof and the endure's debug endpoints
+func (e *Endure) adminMux() *http.ServeMux {
+	mux := e.admin.Mux
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("/debug/endure/graph", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		_, _ = w.Write([]byte(e.DotGraph()))
+	})
+	mux.HandleFunc("/debug/endure/plugins", jsonHandler(func() any {
+		return e.PluginStates()
+	}))
+	mux.HandleFunc("/debug/endure/state", jsonHandler(func() any {
+		return e.State()
+	}))
+	mux.HandleFunc("/debug/endure/report", jsonHandler(func() any {
+		return e.Report()
+	}))
+
+	if e.admin.Control {
+		mux.HandleFunc("POST /debug/endure/plugins/{plugin}/stop", controlHandler(e.stopPlugin))
+		mux.HandleFunc("POST /debug/endure/plugins/{plugin}/restart", controlHandler(e.restartPlugin))
+	}
+
+	return mux
+}
+
+// stopServers gracefully shuts down the admin, the health probes and the metrics servers, should be called without the container lock held
+func (e *Endure) stopServers() {
+	e.mu.Lock()
+	servers := e.servers
+	e.servers = nil
+	e.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.stopTimeout)
+	defer cancel()
+
+	for _, srv := range servers {
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			e.log.Error("server shutdown error", zap.Error(err))
+		}
+	}
+}
+
+// jsonHandler writes the value as a JSON
+func jsonHandler(value func() any) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(value())
+	}
+}
@@ -0,0 +1,11 @@
+package endure
+
+// AmbiguityPolicy declares what to do when several plugins implement the unqualified Init argument
+type AmbiguityPolicy uint8
+
+const (
+	// AmbiguityWeight passes the implementation with the highest weight (default)
+	AmbiguityWeight AmbiguityPolicy = iota
+	// AmbiguityFail fails the Init, the argument should be qualified via the dep.Named
+	AmbiguityFail
+)
@@ -1,42 +1,121 @@
 package endure
 
 import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/errors"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
 )
 
 func (e *Endure) collects() error {
 	vertices := e.graph.TopologicalOrder()
 
+	ctx, span := e.startPhase(context.Background(), "collects")
+
 	for i := range vertices {
 		if !vertices[i].IsActive() {
 			continue
 		}
 
-		if _, ok := vertices[i].Plugin().(Collector); !ok {
-			continue
+		err := e.collectsVertex(ctx, vertices[i])
+		if err != nil {
+			endSpan(span, err)
+			return err
 		}
+	}
 
-		// in deps
-		collects := vertices[i].Plugin().(Collector).Collects()
+	span.End()
 
-		// get vals
-		for j := range collects {
-			impl := e.registar.ImplementsExcept(collects[j].Type, vertices[i].Plugin())
-			if len(impl) == 0 {
-				continue
-			}
+	return nil
+}
+
+// collectsVertex passes all implementations of the collected types to the collector's callbacks
+func (e *Endure) collectsVertex(ctx context.Context, vertex *graph.Vertex) error {
+	if _, ok := vertex.Plugin().(Collector); !ok {
+		return nil
+	}
+
+	// in deps
+	collects := vertex.Plugin().(Collector).Collects()
+
+	// get vals
+	for j := range collects {
+		// dynamic collection, the collector is notified about the plugins joining and leaving after the Init
+		if collects[j].Uncollect != nil {
+			e.dynamic = append(e.dynamic, &dynamicIn{collector: vertex, in: collects[j]})
+		}
 
-			for k := range impl {
-				value, ok := e.registar.TypeValue(impl[k].Plugin(), collects[j].Type)
-				if !ok {
-					return errors.E("this is likely a bug, nil value from the implements. Value should be initialized due to the topological order")
-				}
+		impl := e.registar.ImplementsExcept(collects[j].Type, vertex.Plugin())
+		if len(impl) == 0 {
+			continue
+		}
 
-				// call user's callback
-				collects[j].Callback(value.Interface())
+		for k := range impl {
+			value, ok, err := e.registar.TypeValue(ctx, impl[k].Plugin(), collects[j].Type, impl[k].Method(), impl[k].Name())
+			if err != nil {
+				return e.providerError(impl[k].Plugin(), impl[k].Method(), vertex, collects[j].Type, err)
+			}
+			if !ok {
+				return errors.E("this is likely a bug, nil value from the implements. Value should be initialized due to the topological order")
+			}
+
+			// call user's callback
+			err = e.collect(ctx, vertex, collects[j], impl[k].Plugin(), impl[k].Name(), value)
+			if err != nil {
+				return err
 			}
 		}
 	}
 
 	return nil
 }
+
+// collect passes the value provided by the source plugin to the collector's callback
+func (e *Endure) collect(ctx context.Context, collector *graph.Vertex, in *dep.In, source any, name string, value reflect.Value) error {
+	const op = errors.Op("endure_collects")
+
+	value = e.decorate(in.Type, value)
+
+	src := e.graph.VertexById(source)
+	if in.Uncollect != nil {
+		e.collected = append(e.collected, &collectedValue{collector: collector, in: in, source: src, name: name, value: value})
+	}
+
+	_, span := e.startCall(ctx, collector, "Collects", collectedKey.String(in.Type.String()), sourceKey.String(src.String()))
+	defer span.End()
+
+	start := time.Now()
+	if in.CallbackE == nil {
+		in.Callback(value.Interface())
+		e.timings.record(collector, phaseCollects, time.Since(start))
+		return nil
+	}
+
+	err := in.CallbackE(value.Interface(), &dep.Source{
+		ID:     src.String(),
+		Name:   name,
+		Weight: src.Weight(),
+	})
+	e.timings.record(collector, phaseCollects, time.Since(start))
+	if err != nil {
+		e.log.Error(
+			"collector rejected the value",
+			zap.String("collector", collector.String()),
+			zap.String("source", src.String()),
+			zap.String("type", in.Type.String()),
+			zap.Error(err),
+		)
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return errors.E(op, errors.Init, errors.Errorf("plugin %s failed to collect %s from the plugin %s: %v", collector.String(), in.Type.String(), src.String(), err))
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package endure
 
 import (
 	"context"
+	"time"
 
 	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/graph"
 )
 
 const (
@@ -26,6 +28,8 @@ type result struct {
 	errCh chan error
 	// error from the channel
 	err error
+	// vertex which returned the error
+	vertex *graph.Vertex
 	// unique vertex id
 	vertexID string
 }
@@ -67,6 +71,23 @@ type (
 		Provides() []*dep.Out
 	}
 
+	// OptionalDependencies is optional to implement, declares the Init arguments which don't disable the plugin when missing
+	// A nil interface is passed to the Init instead of the missing optional dependency
+	OptionalDependencies interface {
+		Optional() []*dep.Opt
+	}
+
+	// Qualified is optional to implement, Qualifiers() declares the names of the particular implementations of the Init arguments
+	// e.g.: dep.Named((*Storage)(nil), "s3") matches only the plugin named "s3" or the value provided via dep.BindNamed("s3", ...)
+	Qualified interface {
+		Qualifiers() []*dep.Qualifier
+	}
+
+	// TimedInit is optional to implement, the returned value overrides the container-wide Init timeout for the plugin
+	TimedInit interface {
+		InitTimeout() time.Duration
+	}
+
 	// Weighted is optional to implement, but when implemented the return value added during the topological sort
 	Weighted interface {
 		Weight() uint
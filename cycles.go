@@ -0,0 +1,83 @@
+package endure
+
+import (
+	"strings"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+)
+
+// CyclePolicy declares what to do with the plugins which form a dependency cycle
+type CyclePolicy uint8
+
+const (
+	// CycleWarn drops the plugins from the cycle (and all plugins depending on them) and logs the cycle path
+	CycleWarn CyclePolicy = iota
+	// CycleFail fails the Init with the *CycleError
+	CycleFail
+)
+
+// CycleStep is a single step of the cycle path: Plugin needs the Interface, which is provided by the next step's Plugin
+type CycleStep struct {
+	// Plugin is the ID of the plugin
+	Plugin string
+	// Interface is the type the plugin depends on
+	Interface string
+	// Edge is the kind of the dependency (Init or Collects)
+	Edge graph.EdgeType
+}
+
+// CycleError is returned from the Init when the dependency graph has cycles
+type CycleError struct {
+	// Cycles are ordered paths, the last step depends on the first one
+	Cycles [][]CycleStep
+}
+
+func (c *CycleError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("dependency cycles detected:")
+	for i := range c.Cycles {
+		sb.WriteString("\n\t")
+		sb.WriteString(cyclePath(c.Cycles[i]))
+	}
+
+	return sb.String()
+}
+
+// cyclePath formats the cycle as: plugin -[interface, edge]-> plugin -> ... -> first plugin
+func cyclePath(steps []CycleStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for i := range steps {
+		sb.WriteString(steps[i].Plugin)
+		sb.WriteString(" -[")
+		sb.WriteString(steps[i].Interface)
+		sb.WriteString(", ")
+		sb.WriteString(string(steps[i].Edge))
+		sb.WriteString("]-> ")
+	}
+	sb.WriteString(steps[0].Plugin)
+
+	return sb.String()
+}
+
+// cycleSteps converts graph cycles to the dependency direction: consumer -> interface -> provider
+func cycleSteps(cycles []graph.Cycle) [][]CycleStep {
+	res := make([][]CycleStep, 0, len(cycles))
+	for _, cycle := range cycles {
+		steps := make([]CycleStep, 0, len(cycle))
+		// graph edges are directed from the provider to the consumer, walk them backwards
+		for i := len(cycle) - 1; i >= 0; i-- {
+			steps = append(steps, CycleStep{
+				Plugin:    cycle[i].Dest.String(),
+				Interface: cycle[i].Via.String(),
+				Edge:      cycle[i].Kind,
+			})
+		}
+		res = append(res, steps)
+	}
+
+	return res
+}
@@ -0,0 +1,61 @@
+package endure
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/roadrunner-server/errors"
+	"go.uber.org/zap"
+)
+
+// decorator wraps the values of the interface type before the injection
+type decorator struct {
+	tp   reflect.Type
+	fn   reflect.Value
+	name string
+}
+
+// Decorate registers the decorator, e.g.: func(Storage) Storage, which wraps every value of the interface type resolved for the Init arguments and the Collects
+// Decorators of the same type are applied in the registration order: the first registered is the innermost one
+// Decorators should be registered before the Init
+func (e *Endure) Decorate(fn any) error {
+	const op = errors.Op("endure_decorate")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return errors.E(op, errors.Register, errors.Errorf("decorator should be a function, got: %T", fn))
+	}
+
+	t := v.Type()
+	if t.NumIn() != 1 || t.NumOut() != 1 || t.In(0) != t.Out(0) || t.In(0).Kind() != reflect.Interface {
+		return errors.E(op, errors.Register, errors.Errorf("decorator should receive and return the same interface type, e.g.: func(Storage) Storage, got: %s", t.String()))
+	}
+
+	d := &decorator{
+		tp:   t.In(0),
+		fn:   v,
+		name: runtime.FuncForPC(v.Pointer()).Name(),
+	}
+
+	e.decorators = append(e.decorators, d)
+	e.graph.AddDecorator(d.tp, d.name)
+
+	e.log.Debug("decorator registered", zap.String("type", d.tp.String()), zap.String("func", d.name))
+
+	return nil
+}
+
+// decorate applies the decorators of the tp type to the value
+func (e *Endure) decorate(tp reflect.Type, value reflect.Value) reflect.Value {
+	for _, d := range e.decorators {
+		if d.tp != tp {
+			continue
+		}
+
+		value = d.fn.Call([]reflect.Value{value})[0]
+	}
+
+	return value
+}
@@ -7,6 +7,20 @@ import (
 type In struct {
 	Type     reflect.Type
 	Callback func(any)
+	// CallbackE is used instead of the Callback when set, the error aborts the Init
+	CallbackE func(p any, src *Source) error
+	// Uncollect is called when the collected plugin leaves the active set (failed or restarted)
+	// when set, the Callback (CallbackE) is called for the plugins joined after the Init (registered later or restarted)
+	Uncollect func(p any, src *Source)
+}
+
+// Source describes the plugin, which the collected value came from
+type Source struct {
+	// ID of the plugin, e.g.: *redis.Plugin or *redis.Plugin#cache for the named instance
+	ID string
+	// Name is the qualifier of the value: the plugin's (instance) name or the name of the provided value, might be empty
+	Name   string
+	Weight uint
 }
 
 func Fits(callback func(p any), tp any) *In {
@@ -23,3 +37,17 @@ func Fits(callback func(p any), tp any) *In {
 		Callback: callback,
 	}
 }
+
+// FitsE is the Fits with the callback, which receives the source plugin and might reject the value with the error
+func FitsE(callback func(p any, src *Source) error, tp any) *In {
+	in := Fits(nil, tp)
+	in.CallbackE = callback
+
+	return in
+}
+
+// WithUncollect makes the collection dynamic, see In.Uncollect
+func (in *In) WithUncollect(uncollect func(p any, src *Source)) *In {
+	in.Uncollect = uncollect
+	return in
+}
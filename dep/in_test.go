@@ -19,3 +19,64 @@ func TestImplements(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestCollect(t *testing.T) {
+	called := false
+	in := Collect(func(p FooBar) {
+		called = true
+	})
+
+	if in.Type != reflect.TypeFor[FooBar]() {
+		t.Fail()
+	}
+
+	in.Callback(&TestStruct{})
+	if !called {
+		t.Fail()
+	}
+}
+
+func TestCollectNotInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+
+	Collect(func(p *TestStruct) {})
+}
+
+func TestFitsE(t *testing.T) {
+	in := FitsE(func(p any, src *Source) error {
+		return nil
+	}, (*BarBaz)(nil))
+
+	if in.Callback != nil || in.CallbackE == nil {
+		t.Fail()
+	}
+}
+
+func TestCollectE(t *testing.T) {
+	var source *Source
+	in := CollectE(func(p FooBar, src *Source) error {
+		source = src
+		return nil
+	})
+
+	if in.Type != reflect.TypeFor[FooBar]() {
+		t.Fail()
+	}
+
+	err := in.CallbackE(&TestStruct{}, &Source{ID: "foo", Weight: 10})
+	if err != nil || source == nil || source.ID != "foo" || source.Weight != 10 {
+		t.Fail()
+	}
+}
+
+func TestWithUncollect(t *testing.T) {
+	in := Fits(func(p any) {}, (*BarBaz)(nil)).WithUncollect(func(p any, src *Source) {})
+
+	if in.Uncollect == nil {
+		t.Fail()
+	}
+}
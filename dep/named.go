@@ -0,0 +1,44 @@
+package dep
+
+import (
+	"reflect"
+)
+
+// Qualifier requests the particular named implementation of the Init argument
+type Qualifier struct {
+	Type reflect.Type
+	Name string
+}
+
+// Named qualifies the Init argument of the interface type, e.g.: dep.Named((*Storage)(nil), "s3")
+// Only the plugin with the same name (Named interface) or the value provided via dep.BindNamed matches it
+func Named(tp any, name string) *Qualifier {
+	if reflect.TypeOf(tp) == nil {
+		panic("nil type provided, should be of the form of: (*FooBar)(nil), not (FooBar)(nil)")
+	}
+
+	if reflect.TypeOf(tp).Elem().Kind() != reflect.Interface {
+		panic("type should be of the Interface type")
+	}
+
+	return &Qualifier{
+		Type: reflect.TypeOf(tp).Elem(),
+		Name: name,
+	}
+}
+
+// QualifiedTypes returns the qualifiers of the Init arguments declared by the plugin
+func QualifiedTypes(plugin any) map[reflect.Type]string {
+	q, ok := plugin.(interface{ Qualifiers() []*Qualifier })
+	if !ok {
+		return nil
+	}
+
+	qualifiers := q.Qualifiers()
+	res := make(map[reflect.Type]string, len(qualifiers))
+	for i := range qualifiers {
+		res[qualifiers[i].Type] = qualifiers[i].Name
+	}
+
+	return res
+}
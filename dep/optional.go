@@ -0,0 +1,42 @@
+package dep
+
+import (
+	"reflect"
+)
+
+// Opt declares the optional Init dependency
+type Opt struct {
+	Type reflect.Type
+}
+
+// Optional marks the Init argument of the interface type as optional, e.g.: dep.Optional((*Metrics)(nil))
+// A nil interface is passed to the Init when there are no plugins implementing it
+func Optional(tp any) *Opt {
+	if reflect.TypeOf(tp) == nil {
+		panic("nil type provided, should be of the form of: (*FooBar)(nil), not (FooBar)(nil)")
+	}
+
+	if reflect.TypeOf(tp).Elem().Kind() != reflect.Interface {
+		panic("type should be of the Interface type")
+	}
+
+	return &Opt{
+		Type: reflect.TypeOf(tp).Elem(),
+	}
+}
+
+// OptionalTypes returns the set of the optional Init dependencies declared by the plugin
+func OptionalTypes(plugin any) map[reflect.Type]struct{} {
+	od, ok := plugin.(interface{ Optional() []*Opt })
+	if !ok {
+		return nil
+	}
+
+	opts := od.Optional()
+	res := make(map[reflect.Type]struct{}, len(opts))
+	for i := range opts {
+		res[opts[i].Type] = struct{}{}
+	}
+
+	return res
+}
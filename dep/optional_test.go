@@ -0,0 +1,25 @@
+package dep
+
+import (
+	"reflect"
+	"testing"
+)
+
+type optPlugin struct{}
+
+func (p *optPlugin) Optional() []*Opt {
+	return []*Opt{
+		Optional((*FooBar)(nil)),
+	}
+}
+
+func TestOptionalTypes(t *testing.T) {
+	types := OptionalTypes(&optPlugin{})
+	if _, ok := types[reflect.TypeFor[FooBar]()]; !ok {
+		t.Fail()
+	}
+
+	if OptionalTypes(&Plugin{}) != nil {
+		t.Fail()
+	}
+}
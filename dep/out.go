@@ -1,6 +1,7 @@
 package dep
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -11,8 +12,14 @@ import (
 type Out struct {
 	Type   reflect.Type
 	Method string
+	// Name is the qualifier of the provided value, plugin's name is used when empty
+	Name string
+	// Func is the function returning the value (see Provide), when not set, the value is obtained via the Method of the plugin
+	Func reflect.Value
 }
 
+// Bind binds the provided interface type to the plugin's method
+// The method returns the implementation of the interface and optionally the error, it might receive the context.Context: func(ctx context.Context) (Foo, error)
 func Bind(tp any, method any) *Out {
 	if reflect.TypeOf(tp) == nil {
 		panic("nil type provided, should be of the form of: (*FooBar)(nil), not (FooBar)(nil)")
@@ -27,19 +34,16 @@ func Bind(tp any, method any) *Out {
 		panic("second argument should be a function")
 	}
 
-	for out := range r.Outs() {
-		// skip errors
-		if out == reflect.TypeFor[error]() {
-			continue
-		}
+	if r.NumOut() == 0 || r.NumOut() > 2 || (r.NumOut() == 2 && r.Out(1) != reflect.TypeFor[error]()) {
+		panic("provided method should return the value and optionally the error: func() (Foo, error)")
+	}
 
-		if !out.Implements(reflect.TypeOf(tp).Elem()) {
-			panic("provided method should return an implementation of the provided interface")
-		}
+	if !r.Out(0).Implements(reflect.TypeOf(tp).Elem()) {
+		panic("provided method should return an implementation of the provided interface")
 	}
 
-	if r.NumIn() > 0 {
-		panic("dep.Bind function should not receive any arguments")
+	if r.NumIn() > 1 || (r.NumIn() == 1 && r.In(0) != reflect.TypeFor[context.Context]()) {
+		panic("dep.Bind function should not receive any arguments except the context.Context")
 	}
 
 	return &Out{
@@ -48,6 +52,14 @@ func Bind(tp any, method any) *Out {
 	}
 }
 
+// BindNamed is the Bind with the qualifier, consumers might request this particular value via the dep.Named
+func BindNamed(name string, tp any, method any) *Out {
+	out := Bind(tp, method)
+	out.Name = name
+
+	return out
+}
+
 func getFunctionName(i any) string {
 	rawName := runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
 	name := strings.TrimPrefix(filepath.Ext(rawName), ".")
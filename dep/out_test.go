@@ -1,6 +1,7 @@
 package dep
 
 import (
+	"context"
 	"reflect"
 	"testing"
 )
@@ -33,3 +34,58 @@ func TestOutType(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestOutNamed(t *testing.T) {
+	p := Plugin{}
+	tt := BindNamed("foo", (*FooBar)(nil), p.F)
+
+	if tt.Name != "foo" || tt.Method != "F" {
+		t.Fail()
+	}
+}
+
+func (p *Plugin) FooBar() FooBar {
+	return &TestStruct{}
+}
+
+func TestProvide(t *testing.T) {
+	p := &Plugin{}
+	tt := Provide(p.FooBar)
+
+	if tt.Type != reflect.TypeFor[FooBar]() || tt.Method != "FooBar" || !tt.Func.IsValid() {
+		t.Fail()
+	}
+
+	named := ProvideNamed("foo", p.FooBar)
+	if named.Name != "foo" {
+		t.Fail()
+	}
+}
+
+func (p *Plugin) WithContext(context.Context) (*TestStruct, error) {
+	return &TestStruct{}, nil
+}
+
+func (p *Plugin) TooMany() (*TestStruct, *TestStruct) {
+	return &TestStruct{}, &TestStruct{}
+}
+
+func TestOutContext(t *testing.T) {
+	p := Plugin{}
+	tt := Bind((*FooBar)(nil), p.WithContext)
+
+	if tt.Method != "WithContext" {
+		t.Fail()
+	}
+}
+
+func TestOutInvalidResults(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+
+	p := Plugin{}
+	Bind((*FooBar)(nil), p.TooMany)
+}
@@ -0,0 +1,78 @@
+package dep
+
+import (
+	"context"
+	"reflect"
+)
+
+// Provide is the type-safe Bind: the provided type is T and the value is obtained by calling the fn (usually the plugin's method value)
+// T might be an interface or the concrete type implementing the interfaces requested by the consumers
+func Provide[T any](fn func() T) *Out {
+	if fn == nil {
+		panic("nil function provided")
+	}
+
+	return &Out{
+		Type:   reflect.TypeFor[T](),
+		Method: getFunctionName(fn),
+		Func:   reflect.ValueOf(fn),
+	}
+}
+
+// ProvideContext is the Provide for the provider, which receives the Init context and might fail
+// The error fails the Init of the consumer
+func ProvideContext[T any](fn func(ctx context.Context) (T, error)) *Out {
+	if fn == nil {
+		panic("nil function provided")
+	}
+
+	return &Out{
+		Type:   reflect.TypeFor[T](),
+		Method: getFunctionName(fn),
+		Func:   reflect.ValueOf(fn),
+	}
+}
+
+// ProvideNamed is the Provide with the qualifier, consumers might request this particular value via the dep.Named
+func ProvideNamed[T any](name string, fn func() T) *Out {
+	out := Provide(fn)
+	out.Name = name
+
+	return out
+}
+
+// Collect is the type-safe Fits: fn receives every implementation of the interface T
+func Collect[T any](fn func(T)) *In {
+	if fn == nil {
+		panic("nil function provided")
+	}
+
+	if reflect.TypeFor[T]().Kind() != reflect.Interface {
+		panic("collected type should be of the Interface type")
+	}
+
+	return &In{
+		Type: reflect.TypeFor[T](),
+		Callback: func(p any) {
+			fn(p.(T))
+		},
+	}
+}
+
+// CollectE is the typed FitsE: fn receives every implementation of the interface T with its source plugin, the error aborts the Init
+func CollectE[T any](fn func(v T, src *Source) error) *In {
+	if fn == nil {
+		panic("nil function provided")
+	}
+
+	if reflect.TypeFor[T]().Kind() != reflect.Interface {
+		panic("collected type should be of the Interface type")
+	}
+
+	return &In{
+		Type: reflect.TypeFor[T](),
+		CallbackE: func(p any, src *Source) error {
+			return fn(p.(T), src)
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package endure
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+)
+
+// DisableKind is the kind of the reason why the plugin was disabled
+type DisableKind string
+
+const (
+	// DisabledMissingDependency one or more Init dependencies have no implementations, see the Missing
+	DisabledMissingDependency DisableKind = "MissingDependency"
+	// DisabledByInit plugin's Init returned errors.Disabled
+	DisabledByInit DisableKind = "InitDisabled"
+	// DisabledCascade plugin (transitively) depends on the disabled plugin, see the Root
+	DisabledCascade DisableKind = "Cascade"
+	// DisabledCycle plugin is a part of the dependency cycle, see the Cycle
+	DisabledCycle DisableKind = "Cycle"
+	// DisabledJoinFailed plugin registered after the Init failed to join the container, the error is returned from the Register
+	DisabledJoinFailed DisableKind = "JoinFailed"
+)
+
+// DisableReason explains why the plugin was disabled
+type DisableReason struct {
+	Kind DisableKind `json:"kind"`
+	// Missing is the Init dependency types without implementations
+	Missing []string `json:"missing,omitempty"`
+	// Root is the ID of the disabled plugin (or the plugin of the cycle), which caused the cascade
+	Root string `json:"root,omitempty"`
+	// Cycle is the dependency cycle the plugin is a part of
+	Cycle []CycleStep `json:"cycle,omitempty"`
+}
+
+func (r *DisableReason) String() string {
+	switch r.Kind {
+	case DisabledMissingDependency:
+		return "not enough Init dependencies, missing: " + strings.Join(r.Missing, ", ")
+	case DisabledByInit:
+		return "Init returned errors.Disabled"
+	case DisabledCascade:
+		return "root plugin " + r.Root + " was disabled"
+	case DisabledCycle:
+		return "dependency cycle: " + cyclePath(r.Cycle)
+	case DisabledJoinFailed:
+		return "failed to join the container"
+	default:
+		return string(r.Kind)
+	}
+}
+
+// Why returns the reason why the plugin was disabled, nil if the plugin is not disabled (or not registered)
+// plugin is the registered plugin value or its name as returned from the Plugins (instance name, Named or the ID)
+func (e *Endure) Why(plugin any) *DisableReason {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, v := range e.graph.Registered() {
+		if v.Plugin() == plugin {
+			return e.disabled[v]
+		}
+
+		id, ok := plugin.(string)
+		if !ok {
+			continue
+		}
+
+		if slices.Contains(vertexIDs(v), id) {
+			return e.disabled[v]
+		}
+	}
+
+	return nil
+}
+
+// vertexIDs returns the IDs the plugin might be referred by: the vertex ID, the instance name and the Named name
+func vertexIDs(v *graph.Vertex) []string {
+	ids := []string{v.String()}
+	if v.Name() != "" {
+		ids = append(ids, v.Name())
+	}
+
+	if val, ok := pluginAs[Named](v); ok {
+		ids = append(ids, val.Name())
+	}
+
+	return ids
+}
+
+// disableCycles records the reasons of the plugins excluded from the topological order: plugins of the cycles and their dependents
+func (e *Endure) disableCycles(cycles []graph.Cycle, excluded []*graph.Vertex) {
+	steps := cycleSteps(cycles)
+	for i := range cycles {
+		for _, hop := range cycles[i] {
+			if _, ok := e.disabled[hop.Dest]; !ok {
+				e.disabled[hop.Dest] = &DisableReason{Kind: DisabledCycle, Cycle: steps[i]}
+			}
+		}
+	}
+
+	for i := range cycles {
+		for _, hop := range cycles[i] {
+			for _, v := range e.graph.Reachable(hop.Dest.Plugin()) {
+				if _, ok := e.disabled[v]; !ok {
+					e.disabled[v] = &DisableReason{Kind: DisabledCascade, Root: hop.Dest.String()}
+				}
+			}
+		}
+	}
+
+	// should not happen, every excluded plugin is a part of the cycle or depends on it
+	for _, v := range excluded {
+		if _, ok := e.disabled[v]; !ok {
+			e.disabled[v] = &DisableReason{Kind: DisabledCycle}
+		}
+	}
+}
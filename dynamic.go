@@ -0,0 +1,198 @@
+package endure
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/errors"
+	"go.uber.org/zap"
+)
+
+// dynamicIn is the collected type of the collector, which is notified about the plugins joining and leaving after the Init
+type dynamicIn struct {
+	collector *graph.Vertex
+	in        *dep.In
+}
+
+// collectedValue is the value passed to the dynamic collector
+type collectedValue struct {
+	collector *graph.Vertex
+	in        *dep.In
+	source    *graph.Vertex
+	name      string
+	value     reflect.Value
+}
+
+// collectFrom passes the values provided by the source plugin to the dynamic collectors
+func (e *Endure) collectFrom(ctx context.Context, source *graph.Vertex) error {
+	for _, d := range e.dynamic {
+		if d.collector == source || !d.collector.IsActive() {
+			continue
+		}
+
+		impl := e.registar.ImplementsExcept(d.in.Type, d.collector.Plugin())
+		for k := range impl {
+			if impl[k].Plugin() != source.Plugin() {
+				continue
+			}
+
+			value, ok, err := e.registar.TypeValue(ctx, impl[k].Plugin(), d.in.Type, impl[k].Method(), impl[k].Name())
+			if err != nil {
+				return e.providerError(impl[k].Plugin(), impl[k].Method(), d.collector, d.in.Type, err)
+			}
+			if !ok {
+				continue
+			}
+
+			err = e.collect(ctx, d.collector, d.in, source.Plugin(), impl[k].Name(), value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// uncollect notifies the dynamic collectors that the source plugin left the active set
+// values collected by the source itself are kept: the stopped (or restarted) collector stays registered and keeps its state
+func (e *Endure) uncollect(source *graph.Vertex) {
+	kept := make([]*collectedValue, 0, len(e.collected))
+	for _, c := range e.collected {
+		if c.source != source {
+			kept = append(kept, c)
+			continue
+		}
+
+		e.log.Debug(
+			"uncollecting the plugin",
+			zap.String("collector", c.collector.String()),
+			zap.String("source", source.String()),
+			zap.String("type", c.in.Type.String()),
+		)
+
+		c.in.Uncollect(c.value.Interface(), &dep.Source{
+			ID:     source.String(),
+			Name:   c.name,
+			Weight: source.Weight(),
+		})
+	}
+
+	e.collected = kept
+}
+
+// forget drops the dynamic collects and the collected values of the collector removed from the container
+func (e *Endure) forget(collector *graph.Vertex) {
+	e.dynamic = slices.DeleteFunc(e.dynamic, func(d *dynamicIn) bool {
+		return d.collector == collector
+	})
+
+	e.collected = slices.DeleteFunc(e.collected, func(c *collectedValue) bool {
+		return c.collector == collector
+	})
+}
+
+// join initializes the plugin registered after the Init: resolves its dependencies, calls the Init, passes it to the collectors and serves it if the container is serving
+func (e *Endure) join(vertex *graph.Vertex) (err error) {
+	const op = errors.Op("endure_join")
+
+	ctx, span := e.startPhase(context.Background(), "join")
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	initMethod, ok := graph.InitMethod(vertex.Plugin())
+	if !ok {
+		e.discard(vertex)
+		return errors.E(op, errors.Init, errors.Str("plugin should have the `Init(...) error` method"))
+	}
+
+	deps := graph.InitDeps(initMethod)
+	for _, arg := range deps {
+		if arg.Kind() != reflect.Interface && !isInterfaceSlice(arg) {
+			e.discard(vertex)
+			return errors.E(op, errors.Init, errors.Errorf("argument passed to the Init should be of the Interface type or a slice of interfaces, got: %s", arg.String()))
+		}
+	}
+
+	call, err := e.prepareInit(ctx, vertex)
+	if err != nil {
+		e.discard(vertex)
+		return errors.E(op, err)
+	}
+
+	// disabled, not enough dependencies
+	if call == nil {
+		return nil
+	}
+
+	ret, err := e.callInit(ctx, call)
+	if err == nil {
+		err = e.finishInit(call, ret)
+	}
+	if err != nil {
+		e.discard(vertex)
+		return errors.E(op, errors.Init, err)
+	}
+
+	// Init returned errors.Disabled
+	if !vertex.IsActive() {
+		return nil
+	}
+
+	// edges to keep the stop order and the dependents for the restarts
+	qualifiers := dep.QualifiedTypes(vertex.Plugin())
+	for _, tp := range deps {
+		name := qualifiers[tp]
+		if isInterfaceSlice(tp) {
+			tp = tp.Elem()
+			name = ""
+		}
+
+		res := e.registar.ImplementsNamed(tp, vertex.Plugin(), name)
+		for k := range res {
+			e.graph.AddEdge(graph.InitConnection, res[k].Plugin(), vertex.Plugin(), tp)
+		}
+	}
+
+	e.graph.Append(vertex)
+
+	err = e.collectsVertex(ctx, vertex)
+	if err != nil {
+		e.discard(vertex)
+		return errors.E(op, err)
+	}
+
+	err = e.collectFrom(ctx, vertex)
+	if err != nil {
+		e.discard(vertex)
+		return errors.E(op, err)
+	}
+
+	if e.serving.Load() {
+		err = e.serveVertex(ctx, vertex)
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+
+	e.log.Debug("plugin joined", zap.String("plugin", vertex.String()))
+
+	return nil
+}
+
+// discard removes the plugin, which failed to join
+func (e *Endure) discard(vertex *graph.Vertex) {
+	del := e.graph.Remove(vertex.Plugin())
+	for i := range del {
+		// the plugin might be partially collected before the failure
+		e.uncollect(del[i])
+		e.forget(del[i])
+		e.registar.Remove(del[i].Plugin())
+	}
+
+	e.emitDisabled(del, &DisableReason{Kind: DisabledJoinFailed})
+}
@@ -2,7 +2,10 @@ package endure
 
 import (
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/roadrunner-server/endure/v2/dep"
 	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/errors"
 	"go.uber.org/zap"
@@ -19,11 +22,17 @@ func (e *Endure) resolveCollectorEdges(plugin any) error {
 		res := e.registar.ImplementsExcept(inEntries[i].Type, plugin)
 		if len(res) > 0 {
 			for j := range res {
-				e.graph.AddEdge(graph.CollectsConnection, res[j].Plugin(), plugin)
+				e.graph.AddEdge(graph.CollectsConnection, res[j].Plugin(), plugin, inEntries[i].Type)
+				e.emit(&Event{
+					Type:   EventEdgeResolved,
+					Plugin: e.graph.VertexById(res[j].Plugin()).String(),
+					Dest:   e.graph.VertexById(plugin).String(),
+					Edge:   graph.CollectsConnection,
+				})
 				e.log.Debug("collects edge found",
 					zap.String("method", res[j].Method()),
-					zap.String("src", e.graph.VertexById(res[j].Plugin()).ID().String()),
-					zap.String("dest", e.graph.VertexById(plugin).ID().String()))
+					zap.String("src", e.graph.VertexById(res[j].Plugin()).String()),
+					zap.String("dest", e.graph.VertexById(plugin).String()))
 			}
 		}
 	}
@@ -31,82 +40,150 @@ func (e *Endure) resolveCollectorEdges(plugin any) error {
 	return nil
 }
 
-// resolveEdges adds edges between the vertices
-// At this point, we know all plugins and all 'provides' values
-func (e *Endure) resolveEdges() error {
-	vertices := e.graph.Vertices()
+// resolveVertexEdges adds edges from the Init dependencies and the collected plugins to the vertex
+func (e *Endure) resolveVertexEdges(vertex *graph.Vertex) error {
+	initMethod, ok := graph.InitMethod(vertex.Plugin())
+	if !ok {
+		return errors.E("plugin should have the `Init(...) error` method")
+	}
 
-	for i := range vertices {
-		vertex := e.graph.VertexById(vertices[i].Plugin())
-		initMethod, ok := vertex.ID().MethodByName(InitMethodName)
-		if !ok {
-			return errors.E("plugin should have the `Init(...) error` method")
+	args := graph.InitDeps(initMethod)
+	for j := range args {
+		if isPrimitive(args[j].String()) {
+			e.log.Error(
+				"primitive type in the function parameters",
+				zap.String("plugin", vertex.String()),
+				zap.String("type", args[j].String()),
+			)
+
+			return errors.E("Init method should not receive primitive types (like string, int, etc). It should receive only interfaces")
 		}
 
-		args := make([]reflect.Type, initMethod.Type.NumIn())
-		for j := range initMethod.Type.NumIn() {
-			if isPrimitive(initMethod.Type.In(j).String()) {
-				e.log.Error(
-					"primitive type in the function parameters",
-					zap.String("plugin", vertices[i].ID().String()),
-					zap.String("type", initMethod.Type.In(j).String()),
-				)
+		if args[j].Kind() != reflect.Interface && !isInterfaceSlice(args[j]) {
+			return errors.E("argument passed to the Init should be of the Interface type or a slice of interfaces: e.g: func(p *Plugin) Init(io.Writer, []Middleware), not func(p *Plugin) Init(SomeStructure)")
+		}
+	}
 
-				return errors.E("Init method should not receive primitive types (like string, int, etc). It should receive only interfaces")
+	optional := dep.OptionalTypes(vertex.Plugin())
+	qualifiers := dep.QualifiedTypes(vertex.Plugin())
+
+	// we need to have the same number of plugins which implements the needed dep
+	count := 0
+	var missing []string
+	if len(args) > 0 {
+		for j := range args {
+			// slice receives all implementations, might be empty
+			if isInterfaceSlice(args[j]) {
+				count += 1
+				res := e.registar.ImplementsExcept(args[j].Elem(), vertex.Plugin())
+				for k := range res {
+					e.graph.AddEdge(graph.InitConnection, res[k].Plugin(), vertex.Plugin(), args[j].Elem())
+					e.emit(&Event{
+						Type:   EventEdgeResolved,
+						Plugin: e.graph.VertexById(res[k].Plugin()).String(),
+						Dest:   vertex.String(),
+						Edge:   graph.InitConnection,
+					})
+					e.log.Debug(
+						"init slice edge found",
+						zap.String("src", e.graph.VertexById(res[k].Plugin()).String()),
+						zap.String("dest", vertex.String()),
+						zap.String("type", args[j].String()),
+					)
+				}
+
+				continue
 			}
 
-			// check kind only for the 1..n In types (0-th is always receiver)
-			if j > 0 {
-				if initMethod.Type.In(j).Kind() != reflect.Interface {
-					return errors.E("argument passed to the Init should be of the Interface type: e.g: func(p *Plugin) Init(io.Writer), not func(p *Plugin) Init(SomeStructure)")
+			res := e.registar.ImplementsNamed(args[j], vertex.Plugin(), qualifiers[args[j]])
+			if qualifiers[args[j]] == "" && e.ambiguityPolicy == AmbiguityFail && distinctPlugins(res) > 1 {
+				names := make([]string, 0, len(res))
+				for k := range res {
+					names = append(names, e.graph.VertexById(res[k].Plugin()).String())
 				}
+
+				return errors.E(errors.Errorf(
+					"plugin %s: Init argument %s is implemented by several plugins: %s, qualify it with the dep.Named",
+					vertex.String(), args[j].String(), strings.Join(names, ", ")),
+				)
 			}
 
-			args[j] = initMethod.Type.In(j)
-		}
+			if _, ok := optional[args[j]]; ok && len(res) == 0 {
+				// optional dependency, nil will be passed to the Init
+				count += 1
+				e.log.Info(
+					"optional Init dependency is missing",
+					zap.String("plugin", vertex.String()),
+					zap.String("type", args[j].String()),
+				)
+				e.emit(&Event{Type: EventOptionalMissing, Plugin: vertex.String(), Reason: args[j].String()})
+				continue
+			}
 
-		// we need to have the same number of plugins which implements the needed dep
-		count := 0
-		if len(args) > 1 {
-			for j := 1; j < len(args); j++ {
-				res := e.registar.ImplementsExcept(args[j], vertices[i].Plugin())
-				if len(res) > 0 {
-					count += 1
-					for k := range res {
-						// add graph edge
-						e.graph.AddEdge(graph.InitConnection, res[k].Plugin(), vertex.Plugin())
-						// log
-						e.log.Debug(
-							"init edge found",
-							zap.Any("src", e.graph.VertexById(res[k].Plugin()).ID().String()),
-							zap.Any("dest", e.graph.VertexById(vertex.Plugin()).ID().String()),
-						)
-					}
-				}
+			if len(res) == 0 {
+				missing = append(missing, args[j].String())
 			}
 
-			// we should have here exactly the same number of the deps implementing every particular arg
-			if count != len(args[1:]) {
-				// if there are no plugins that implement Init deps, remove this vertex from the tree
-				del := e.graph.Remove(vertices[i].Plugin())
-				for k := range del {
-					e.registar.Remove(del[k].Plugin())
+			if len(res) > 0 {
+				count += 1
+				for k := range res {
+					// add graph edge
+					e.graph.AddEdge(graph.InitConnection, res[k].Plugin(), vertex.Plugin(), args[j])
+					e.emit(&Event{
+						Type:   EventEdgeResolved,
+						Plugin: e.graph.VertexById(res[k].Plugin()).String(),
+						Dest:   vertex.String(),
+						Edge:   graph.InitConnection,
+					})
+					// log
 					e.log.Debug(
-						"plugin disabled, not enough Init dependencies",
-						zap.String("name", del[k].ID().String()),
+						"init edge found",
+						zap.Any("src", e.graph.VertexById(res[k].Plugin()).String()),
+						zap.Any("dest", e.graph.VertexById(vertex.Plugin()).String()),
 					)
 				}
+			}
+		}
 
-				continue
+		// we should have here exactly the same number of the deps implementing every particular arg
+		if count != len(args) {
+			// if there are no plugins that implement Init deps, remove this vertex from the tree
+			del := e.graph.Remove(vertex.Plugin())
+			for k := range del {
+				e.registar.Remove(del[k].Plugin())
+				e.log.Debug(
+					"plugin disabled, not enough Init dependencies",
+					zap.String("name", del[k].String()),
+				)
 			}
+			e.emitDisabled(del, &DisableReason{Kind: DisabledMissingDependency, Missing: missing})
+
+			return nil
 		}
+	}
+
+	// we don't have a collector() method
+	if _, okc := vertex.Plugin().(Collector); !okc {
+		return nil
+	}
+
+	return e.resolveCollectorEdges(vertex.Plugin())
+}
+
+// resolveEdges adds edges between the vertices
+// At this point, we know all plugins and all 'provides' values
+func (e *Endure) resolveEdges() error {
+	vertices := e.graph.Vertices()
 
-		// we don't have a collector() method
-		if _, okc := vertices[i].Plugin().(Collector); !okc {
+	for i := range vertices {
+		// might be disabled by the previous vertex
+		if !vertices[i].IsActive() {
 			continue
 		}
 
-		err := e.resolveCollectorEdges(vertices[i].Plugin())
+		start := time.Now()
+		err := e.resolveVertexEdges(vertices[i])
+		e.timings.record(vertices[i], phaseResolve, time.Since(start))
 		if err != nil {
 			return err
 		}
@@ -117,19 +194,37 @@ func (e *Endure) resolveEdges() error {
 	// to notify user about the disabled plugins
 	// after topological sorting, we remove all plugins with indegree > 0, because there are no edges to them
 	if len(e.graph.TopologicalOrder()) != len(e.graph.Vertices()) {
+		gc := e.graph.Cycles()
+		cycles := cycleSteps(gc)
+		if len(cycles) > 0 && e.cyclePolicy == CycleFail {
+			return &CycleError{Cycles: cycles}
+		}
+
+		for i := range cycles {
+			e.log.Warn("dependency cycle detected", zap.String("cycle", cyclePath(cycles[i])))
+		}
+
 		tpl := e.graph.TopologicalOrder()
 		vrt := e.graph.Vertices()
 
 		tmpM := make(map[string]struct{}, 2)
 		for _, v := range tpl {
-			tmpM[v.ID().String()] = struct{}{}
+			tmpM[v.String()] = struct{}{}
 		}
 
+		var excluded []*graph.Vertex
 		for _, v := range vrt {
-			if _, ok := tmpM[v.ID().String()]; !ok {
-				e.log.Warn("topological sort, plugin disabled", zap.String("plugin", v.ID().String()))
+			if _, ok := tmpM[v.String()]; !ok {
+				excluded = append(excluded, v)
 			}
 		}
+
+		e.disableCycles(gc, excluded)
+
+		for _, v := range excluded {
+			e.log.Warn("topological sort, plugin disabled", zap.String("plugin", v.String()), zap.String("reason", e.disabled[v].String()))
+			e.emit(&Event{Type: EventDisabled, Plugin: v.String(), Reason: e.disabled[v].String()})
+		}
 	}
 
 	return nil
@@ -3,16 +3,18 @@ package endure
 import (
 	"log/slog"
 	"net/http"
-	// pprof will be enabled in debug mode
-	"net/http/pprof"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/endure/v2/logger"
 	"github.com/roadrunner-server/endure/v2/registar"
 	"github.com/roadrunner-server/errors"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
 )
 
@@ -27,8 +29,53 @@ type Endure struct {
 	// log
 	log         *zap.Logger
 	stopTimeout time.Duration
-	profiler    bool
+	initTimeout time.Duration
 	visualize   bool
+	cyclePolicy CyclePolicy
+	observers   []Observer
+	decorators  []*decorator
+	timings     *timings
+	// unqualified Init arguments with several implementations
+	ambiguityPolicy AmbiguityPolicy
+
+	// parallel init
+	parallelInit bool
+	initWorkers  int
+
+	// admin (profiler) server
+	admin *AdminConfig
+	// admin, health probes and metrics servers, the start error is returned from the Serve
+	servers    []*http.Server
+	serversErr error
+	// plugins stopped via the admin control endpoint
+	halted map[*graph.Vertex]struct{}
+	// disable reasons
+	disabled   map[*graph.Vertex]*DisableReason
+	lastErrors *lastErrors
+
+	// health probes server address
+	probesAddr string
+	// last snapshot of the active plugins for the probes
+	probed  atomic.Pointer[[]*probedPlugin]
+	serving atomic.Bool
+
+	// lifecycle metrics
+	metricsRegisterer prometheus.Registerer
+	metricsAddr       string
+	// lifecycle tracing
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer
+
+	// restarts
+	supervisor *supervisor
+	stopped    atomic.Bool
+	pollersMu  sync.Mutex
+	pollers    map[*graph.Vertex]chan struct{}
+
+	// dynamic collection
+	initialized bool
+	dynamic     []*dynamicIn
+	collected   []*collectedValue
 
 	// main thread
 	handleErrorCh chan *result
@@ -39,20 +86,23 @@ type Endure struct {
 type Options func(endure *Endure)
 
 // New returns empty endure container
+// level is used for the default logger only, it is ignored when the logger is provided via the Options
 func New(level slog.Leveler, options ...Options) *Endure {
 	if level == nil {
 		level = slog.LevelDebug
 	}
 
-	// error handling is omitted because we are sure that the logger will be created
-	zlog, _ := logger.BuildLogger(level)
-
 	c := &Endure{
 		registar:    registar.New(),
 		graph:       graph.New(),
 		mu:          sync.RWMutex{},
 		stopTimeout: time.Second * 30,
-		log:         zlog.Named("endure"),
+		supervisor:  newSupervisor(),
+		timings:     newTimings(),
+		pollers:     make(map[*graph.Vertex]chan struct{}),
+		halted:      make(map[*graph.Vertex]struct{}),
+		disabled:    make(map[*graph.Vertex]*DisableReason),
+		lastErrors:  &lastErrors{errs: make(map[*graph.Vertex]error)},
 	}
 
 	// Main thread channels
@@ -64,9 +114,23 @@ func New(level slog.Leveler, options ...Options) *Endure {
 		option(c)
 	}
 
-	// start profiler server
-	if c.profiler {
-		profile()
+	if c.log == nil {
+		// error handling is omitted because we are sure that the logger will be created
+		c.log, _ = logger.BuildLogger(level)
+	}
+
+	c.log = c.log.Named("endure")
+
+	if c.tracerProvider == nil {
+		c.tracerProvider = noop.NewTracerProvider()
+	}
+
+	c.tracer = c.tracerProvider.Tracer(tracerName)
+
+	// start admin (profiler), health probes and metrics servers, the bind and the metrics registration errors are returned from the Serve
+	c.serversErr = c.startServers()
+	if c.serversErr != nil {
+		c.log.Error("failed to start the servers", zap.Error(c.serversErr))
 	}
 
 	return c
@@ -78,6 +142,25 @@ func (e *Endure) Register(vertex any) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	return e.register(op, vertex, "")
+}
+
+// RegisterNamed registers the plugin instance with the name, instances of the same type (e.g. two redis plugins: cache and sessions) get independent vertices
+// The name is used as the qualifier of the instance and its provided values (see dep.Named) and is returned from the Plugins
+func (e *Endure) RegisterNamed(name string, vertex any) error {
+	const op = errors.Op("endure_register_named")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if name == "" {
+		return errors.E(op, errors.Register, errors.Str("instance name should not be empty"))
+	}
+
+	return e.register(op, vertex, name)
+}
+
+// register adds the vertex to the graph and its types to the registar, instance is empty for the unnamed plugin
+func (e *Endure) register(op errors.Op, vertex any, instance string) error {
 	t := reflect.TypeOf(vertex)
 
 	// t.Kind() - ptr
@@ -93,8 +176,8 @@ func (e *Endure) Register(vertex any) error {
 	And we fill vertex with this information
 	*/
 
-	if e.graph.HasVertex(vertex) {
-		e.log.Warn("already registered", zap.Error(errors.Errorf("plugin `%s` is already registered", t.String())))
+	if e.graph.HasVertex(vertex) || e.graph.HasInstance(t, instance) {
+		e.log.Warn("already registered", zap.Error(errors.Errorf("plugin `%s` is already registered", t.String())), zap.String("instance", instance))
 		return nil
 	}
 
@@ -109,16 +192,26 @@ func (e *Endure) Register(vertex any) error {
 		)
 	}
 
+	// plugin's name is used as a qualifier of the plugin itself and its provided values
+	// instance name overrides the name of the plugin
+	name := instance
+	if val, ok := vertex.(Named); ok && name == "" {
+		name = val.Name()
+	}
+
 	// push the vertex
-	e.graph.AddVertex(vertex, weight)
+	e.graph.AddNamedVertex(vertex, instance, weight)
 	// add the dependency for the resolver
-	e.registar.Insert(vertex, reflect.TypeOf(vertex), "", weight)
+	e.registar.Insert(vertex, reflect.TypeOf(vertex), "", name, weight)
+
+	e.emit(&Event{Type: EventRegistered, Plugin: e.graph.VertexById(vertex).String()})
 
 	e.log.Debug(
 		"type registered",
 		zap.String("type", reflect.TypeOf(vertex).Elem().String()),
 		zap.String("kind", reflect.TypeOf(vertex).Elem().Kind().String()),
 		zap.String("method", "plugin"),
+		zap.String("instance", instance),
 	)
 
 	/*
@@ -136,7 +229,12 @@ func (e *Endure) Register(vertex any) error {
 
 		// iter
 		for i := range outDeps {
-			e.registar.Insert(vertex, outDeps[i].Type, outDeps[i].Method, weight)
+			qualifier := name
+			if outDeps[i].Name != "" {
+				qualifier = outDeps[i].Name
+			}
+
+			e.registar.Insert(vertex, outDeps[i].Type, outDeps[i].Method, qualifier, weight)
 			e.log.Debug(
 				"provided type registered",
 				zap.String("type", outDeps[i].Type.String()),
@@ -146,6 +244,14 @@ func (e *Endure) Register(vertex any) error {
 		}
 	}
 
+	// plugin registered after the Init joins the running container
+	if e.initialized {
+		err := e.join(e.graph.VertexById(vertex))
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+
 	return nil
 }
 
@@ -175,6 +281,11 @@ func (e *Endure) Init() error {
 	// traverse the graph
 	err := e.resolveEdges()
 	if err != nil {
+		// return the cycles error as is, to be available for the errors.As
+		if cerr, ok := err.(*CycleError); ok {
+			return cerr
+		}
+
 		return errors.E(op, errors.Init, err)
 	}
 
@@ -192,6 +303,8 @@ func (e *Endure) Init() error {
 		return err
 	}
 
+	e.initialized = true
+
 	return nil
 }
 
@@ -203,6 +316,10 @@ func (e *Endure) Serve() (<-chan *Result, error) {
 
 	e.log.Debug("preparing to serve")
 
+	if e.serversErr != nil {
+		return nil, e.serversErr
+	}
+
 	e.startMainThread()
 
 	err := e.serve()
@@ -210,6 +327,8 @@ func (e *Endure) Serve() (<-chan *Result, error) {
 		return nil, err
 	}
 
+	e.serving.Store(true)
+
 	e.log.Debug("serving")
 
 	return e.userResultsCh, nil
@@ -218,6 +337,9 @@ func (e *Endure) Serve() (<-chan *Result, error) {
 // Stop used to shutdown the Endure
 // Do not change this method fn, sync with constants in the beginning of this file
 func (e *Endure) Stop() error {
+	// admin, probes and metrics servers are shut down after the plugins, outside the lock: admin handlers might wait for the container lock
+	defer e.stopServers()
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -227,6 +349,24 @@ func (e *Endure) Stop() error {
 
 	e.log.Debug("calling stop")
 
+	_, err := e.stop()
+	return err
+}
+
+// StopWithReport stops the plugins as the Stop does and returns the per-plugin stop duration and outcome
+// Plugins are reported in the stop order
+func (e *Endure) StopWithReport() ([]*StopResult, error) {
+	defer e.stopServers()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.graph.Vertices()) == 0 {
+		return nil, errors.E(errors.Str("no plugins registered"))
+	}
+
+	e.log.Debug("calling stop")
+
 	return e.stop()
 }
 
@@ -242,33 +382,18 @@ func (e *Endure) Plugins() []string {
 			continue
 		}
 
-		if val, ok := v[i].Plugin().(Named); ok {
+		if v[i].Name() != "" {
+			plugins = append(plugins, v[i].Name())
+			continue
+		}
+
+		if val, ok := pluginAs[Named](v[i]); ok {
 			plugins = append(plugins, val.Name())
 			continue
 		}
 
-		plugins = append(plugins, v[i].ID().String())
+		plugins = append(plugins, v[i].String())
 	}
 
 	return plugins
 }
-
-func profile() {
-	go func() {
-		mux := http.NewServeMux()
-
-		mux.HandleFunc("/debug/pprof/", pprof.Index)
-		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
-
-		srv := &http.Server{
-			ReadHeaderTimeout: time.Minute * 5,
-			Handler:           mux,
-			Addr:              "0.0.0.0:6061",
-		}
-
-		_ = srv.ListenAndServe()
-	}()
-}
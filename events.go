@@ -0,0 +1,97 @@
+package endure
+
+import (
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+)
+
+// EventType is the type of the lifecycle event
+type EventType string
+
+const (
+	// EventRegistered plugin was registered in the container
+	EventRegistered EventType = "Registered"
+	// EventEdgeResolved dependency edge was added to the graph, Plugin is the source (provider), Dest is the consumer
+	EventEdgeResolved EventType = "EdgeResolved"
+	// EventOptionalMissing optional Init dependency has no implementations, Reason is the dependency type
+	EventOptionalMissing EventType = "OptionalMissing"
+	// EventInitStarted plugin's Init is called
+	EventInitStarted EventType = "InitStarted"
+	// EventInitFinished plugin's Init returned, Error is set if Init failed
+	EventInitFinished EventType = "InitFinished"
+	// EventDisabled plugin was disabled, see the Reason
+	EventDisabled EventType = "Disabled"
+	// EventServeStarted plugin's Serve returned
+	EventServeStarted EventType = "ServeStarted"
+	// EventServeError plugin sent an error to the Serve channel
+	EventServeError EventType = "ServeError"
+	// EventStopStarted plugin's Stop is called
+	EventStopStarted EventType = "StopStarted"
+	// EventStopFinished plugin's Stop returned (or timed out), Error is set if Stop failed
+	EventStopFinished EventType = "StopFinished"
+	// EventRestarted plugin was restarted by the supervisor, Error is the cause of the restart (nil if the Serve channel was closed)
+	EventRestarted EventType = "Restarted"
+)
+
+// Event is the container lifecycle event
+type Event struct {
+	Type EventType
+	// Plugin is the vertex ID
+	Plugin string
+	// Dest is the consumer vertex ID for the EventEdgeResolved
+	Dest string
+	// Edge is the edge type for the EventEdgeResolved
+	Edge graph.EdgeType
+	// Reason is the reason of the EventDisabled
+	Reason string
+	// Error is set for the failed calls and EventServeError
+	Error error
+	// Duration is the duration of the call for the *Finished and EventServeStarted events
+	Duration time.Duration
+	// Time when the event was emitted
+	Time time.Time
+}
+
+// Observer receives the container lifecycle events
+// Events are delivered synchronously from the lifecycle goroutines (Init and Stop might run concurrently), so the observer should be fast and thread-safe
+type Observer interface {
+	OnEvent(event *Event)
+}
+
+// ObserverFunc is the function adapter for the Observer
+type ObserverFunc func(event *Event)
+
+func (f ObserverFunc) OnEvent(event *Event) {
+	f(event)
+}
+
+// emit sends the event to all observers
+func (e *Endure) emit(event *Event) {
+	if len(e.observers) == 0 {
+		return
+	}
+
+	event.Time = time.Now()
+	for i := range e.observers {
+		e.observers[i].OnEvent(event)
+	}
+}
+
+// emitDisabled notifies the observers about the removed vertices, the first one is the root of the removal
+// the reasons are kept for the Why
+func (e *Endure) emitDisabled(removed []*graph.Vertex, reason *DisableReason) {
+	for i := range removed {
+		r := reason
+		if i > 0 {
+			r = &DisableReason{Kind: DisabledCascade, Root: removed[0].String()}
+		}
+
+		e.disabled[removed[i]] = r
+		e.emit(&Event{
+			Type:   EventDisabled,
+			Plugin: removed[i].String(),
+			Reason: r.String(),
+		})
+	}
+}
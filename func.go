@@ -0,0 +1,324 @@
+package endure
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/errors"
+	"go.uber.org/zap"
+)
+
+// funcPlugin is the vertex of the constructor registered via the RegisterFunc or the value registered via the Supply
+type funcPlugin struct {
+	fn reflect.Value
+	// constructor name, used as the method of the provided values
+	name string
+	// result types, except the error
+	out []reflect.Type
+	// constructor returns the error as the last result
+	withErr bool
+	// values returned by the constructor, set after the Init
+	values []reflect.Value
+	// synthetic Init method, calls the constructor
+	init reflect.Method
+	// pre-built value, not served and not stopped
+	supplied bool
+}
+
+func newFuncPlugin(fn any) (*funcPlugin, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return nil, errors.Errorf("constructor should be a function, got: %T", fn)
+	}
+
+	t := v.Type()
+	if t.IsVariadic() {
+		return nil, errors.Errorf("variadic constructors are not supported: %s", t.String())
+	}
+
+	fp := &funcPlugin{
+		fn:   v,
+		name: runtime.FuncForPC(v.Pointer()).Name(),
+	}
+
+	for i := range t.NumOut() {
+		if i == t.NumOut()-1 && t.Out(i) == reflect.TypeFor[error]() {
+			fp.withErr = true
+			continue
+		}
+
+		fp.out = append(fp.out, t.Out(i))
+	}
+
+	if len(fp.out) == 0 {
+		return nil, errors.Errorf("constructor should return at least one value: %s", t.String())
+	}
+
+	// receiver + constructor's parameters
+	in := make([]reflect.Type, 0, t.NumIn()+1)
+	in = append(in, reflect.TypeOf(fp))
+	for i := range t.NumIn() {
+		// parameters are resolved as the Init dependencies
+		if t.In(i).Kind() != reflect.Interface && !isInterfaceSlice(t.In(i)) {
+			return nil, errors.Errorf("constructor's parameters should be of the Interface type or a slice of interfaces, got: %s", t.In(i).String())
+		}
+
+		in = append(in, t.In(i))
+	}
+
+	errTp := reflect.TypeFor[error]()
+	ft := reflect.FuncOf(in, []reflect.Type{errTp}, false)
+
+	fp.init = reflect.Method{
+		Name: InitMethodName,
+		Type: ft,
+		Func: reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+			ret := fp.fn.Call(args[1:])
+			if fp.withErr {
+				if !ret[len(ret)-1].IsNil() {
+					return []reflect.Value{ret[len(ret)-1]}
+				}
+
+				ret = ret[:len(ret)-1]
+			}
+
+			fp.values = ret
+			return []reflect.Value{reflect.Zero(errTp)}
+		}),
+	}
+
+	return fp, nil
+}
+
+// newSuppliedPlugin wraps the pre-built value, the value is provided as the interfaces from the as list
+// Init arguments should be interfaces, so the value provided as its own type can't be consumed
+func newSuppliedPlugin(value any, as []any) (*funcPlugin, error) {
+	if value == nil {
+		return nil, errors.Str("supplied value should not be nil")
+	}
+
+	if len(as) == 0 {
+		return nil, errors.Errorf("supplied value of type %T should be provided as at least one interface, e.g.: (*io.Writer)(nil)", value)
+	}
+
+	v := reflect.ValueOf(value)
+	fp := &funcPlugin{
+		name:     "supply(" + v.Type().String() + ")",
+		supplied: true,
+	}
+
+	for i := range as {
+		tp := reflect.TypeOf(as[i])
+		// (*Interface)(nil)
+		if tp == nil || tp.Kind() != reflect.Pointer || tp.Elem().Kind() != reflect.Interface {
+			return nil, errors.Errorf("supplied value should be provided as the interface, e.g.: (*io.Writer)(nil), got: %T", as[i])
+		}
+
+		if !v.Type().Implements(tp.Elem()) {
+			return nil, errors.Errorf("supplied value of type %s does not implement %s", v.Type().String(), tp.Elem().String())
+		}
+
+		fp.out = append(fp.out, tp.Elem())
+		fp.values = append(fp.values, v)
+	}
+
+	// nothing to initialize
+	errTp := reflect.TypeFor[error]()
+	ft := reflect.FuncOf([]reflect.Type{reflect.TypeOf(fp)}, []reflect.Type{errTp}, false)
+	fp.init = reflect.Method{
+		Name: InitMethodName,
+		Type: ft,
+		Func: reflect.MakeFunc(ft, func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.Zero(errTp)}
+		}),
+	}
+
+	return fp, nil
+}
+
+// interfaces returns the values returned by the constructor (or supplied), nil before the constructor's Init
+func (fp *funcPlugin) interfaces() []any {
+	values := make([]any, 0, len(fp.values))
+	for i := range fp.values {
+		values = append(values, fp.values[i].Interface())
+	}
+
+	return values
+}
+
+// InitMethod implements the graph.Initializer
+func (fp *funcPlugin) InitMethod() reflect.Method {
+	return fp.init
+}
+
+// RegisterFunc registers the constructor, e.g.: func(Logger, Config) (*Server, error), where Logger and Config are interfaces
+// Constructor's parameters are resolved as the Init dependencies, its results (except the error) are provided to the other plugins
+// The result implementing the Service is served and stopped as a regular plugin
+func (e *Endure) RegisterFunc(fn any) error {
+	const op = errors.Op("endure_register_func")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.registerFunc(op, fn, "")
+}
+
+// RegisterFuncNamed registers the constructor with the name, so the same constructor (or constructors of the same type) might be registered several times
+// The name is used as the qualifier of the provided values (see dep.Named)
+func (e *Endure) RegisterFuncNamed(name string, fn any) error {
+	const op = errors.Op("endure_register_func_named")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if name == "" {
+		return errors.E(op, errors.Register, errors.Str("instance name should not be empty"))
+	}
+
+	return e.registerFunc(op, fn, name)
+}
+
+// registerFunc adds the constructor's vertex, instance is empty for the unnamed constructor
+func (e *Endure) registerFunc(op errors.Op, fn any, instance string) error {
+	fp, err := newFuncPlugin(fn)
+	if err != nil {
+		return errors.E(op, errors.Register, err)
+	}
+
+	// vertex is identified by the first result type
+	if e.graph.HasInstance(fp.out[0], instance) {
+		e.log.Warn("already registered", zap.Error(errors.Errorf("constructor of the `%s` is already registered", fp.out[0].String())), zap.String("instance", instance))
+		return nil
+	}
+
+	e.insertFuncPlugin(fp, fp.out[0], instance)
+
+	e.log.Debug(
+		"constructor registered",
+		zap.String("func", fp.name),
+		zap.String("type", fp.fn.Type().String()),
+		zap.String("instance", instance),
+	)
+
+	if e.initialized {
+		err = e.join(e.graph.VertexById(fp))
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// Supply registers the pre-built value (e.g. *sql.DB or the parsed configuration) as the dependency of the other plugins
+// The value is provided as the interfaces passed as (*Interface)(nil), at least one interface is required
+// Supplied value has no Init and is not served or stopped by endure
+func (e *Endure) Supply(value any, asInterfaces ...any) error {
+	const op = errors.Op("endure_supply")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.supply(op, value, asInterfaces, "")
+}
+
+// SupplyNamed registers the pre-built value with the name, so several values of the same type (e.g. two *sql.DB) might be supplied
+// The name is used as the qualifier of the provided values (see dep.Named)
+func (e *Endure) SupplyNamed(name string, value any, asInterfaces ...any) error {
+	const op = errors.Op("endure_supply_named")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if name == "" {
+		return errors.E(op, errors.Register, errors.Str("instance name should not be empty"))
+	}
+
+	return e.supply(op, value, asInterfaces, name)
+}
+
+// supply adds the vertex of the supplied value, instance is empty for the unnamed value
+func (e *Endure) supply(op errors.Op, value any, asInterfaces []any, instance string) error {
+	fp, err := newSuppliedPlugin(value, asInterfaces)
+	if err != nil {
+		return errors.E(op, errors.Register, err)
+	}
+
+	if e.graph.HasInstance(reflect.TypeOf(value), instance) {
+		e.log.Warn("already registered", zap.Error(errors.Errorf("value of the `%s` type is already supplied", reflect.TypeOf(value).String())), zap.String("instance", instance))
+		return nil
+	}
+
+	e.insertFuncPlugin(fp, reflect.TypeOf(value), instance)
+
+	e.log.Debug(
+		"value supplied",
+		zap.String("type", reflect.TypeOf(value).String()),
+		zap.Int("interfaces", len(asInterfaces)),
+		zap.String("instance", instance),
+	)
+
+	if e.initialized {
+		err = e.join(e.graph.VertexById(fp))
+		if err != nil {
+			return errors.E(op, err)
+		}
+	}
+
+	return nil
+}
+
+// insertFuncPlugin adds the vertex identified by the id type and registers its provided types, qualified by the instance name
+// the supplied value might be Weighted and Named, the constructor's values are not known until its Init, when the graph is already built
+func (e *Endure) insertFuncPlugin(fp *funcPlugin, id reflect.Type, instance string) {
+	weight := uint(1)
+	if val, ok := valueAs[Weighted](fp.interfaces()); ok {
+		weight = val.Weight()
+	}
+
+	// instance name overrides the name of the value
+	name := instance
+	if val, ok := valueAs[Named](fp.interfaces()); ok && name == "" {
+		name = val.Name()
+	}
+
+	e.graph.AddTypedVertex(fp, id, instance, weight)
+	for i := range fp.out {
+		e.registar.Insert(fp, fp.out[i], fp.name, name, weight)
+	}
+
+	e.emit(&Event{Type: EventRegistered, Plugin: e.graph.VertexById(fp).String()})
+}
+
+// servicePlugin returns the value to call Serve and Stop on: the plugin itself or the Service returned by the constructor
+func servicePlugin(vertex *graph.Vertex) (any, bool) {
+	if fp, ok := vertex.Plugin().(*funcPlugin); ok && fp.supplied {
+		return nil, false
+	}
+
+	return pluginAs[Service](vertex)
+}
+
+// pluginAs returns the effective plugin value implementing T: the plugin itself, or the first value returned by the constructor (or supplied) implementing T
+func pluginAs[T any](vertex *graph.Vertex) (T, bool) {
+	return valueAs[T](pluginValues(vertex))
+}
+
+// pluginValues returns the effective plugin values: the plugin itself, or the values returned by the constructor (or supplied)
+// the constructor's values are known only after its Init
+func pluginValues(vertex *graph.Vertex) []any {
+	if fp, ok := vertex.Plugin().(*funcPlugin); ok {
+		return fp.interfaces()
+	}
+
+	return []any{vertex.Plugin()}
+}
+
+// valueAs returns the first value implementing T
+func valueAs[T any](values []any) (T, bool) {
+	for i := range values {
+		if val, ok := values[i].(T); ok {
+			return val, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
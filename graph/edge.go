@@ -1,5 +1,9 @@
 package graph
 
+import (
+	"reflect"
+)
+
 type EdgeType string
 
 const (
@@ -10,4 +14,6 @@ const (
 type edge struct {
 	src, dest      any
 	connectionType EdgeType
+	// via is the interface type which dest receives from the src
+	via reflect.Type
 }
@@ -1,11 +1,15 @@
 package graph
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
 	"slices"
+	"sort"
 	"strings"
+
+	"github.com/roadrunner-server/endure/v2/dep"
 )
 
 const (
@@ -13,13 +17,55 @@ const (
 	InitMethodName = "Init"
 )
 
+// Initializer is implemented by the plugins without the Init method (e.g. constructors registered via the RegisterFunc)
+type Initializer interface {
+	// InitMethod returns the equivalent of the Init method, the first argument is the receiver (plugin itself)
+	InitMethod() reflect.Method
+}
+
+// InitMethod returns the Init method of the plugin
+func InitMethod(plugin any) (reflect.Method, bool) {
+	if val, ok := plugin.(Initializer); ok {
+		return val.InitMethod(), true
+	}
+
+	return reflect.TypeOf(plugin).MethodByName(InitMethodName)
+}
+
+// ContextType is the type of the context.Context, which might be injected by endure as the first (after the receiver) argument of the Init method
+var ContextType = reflect.TypeFor[context.Context]()
+
+// AcceptsContext returns true if the first (after the receiver) argument of the Init method is the context.Context
+func AcceptsContext(method reflect.Method) bool {
+	return method.Type.NumIn() > 1 && method.Type.In(1) == ContextType
+}
+
+// InitDeps returns the dependencies of the Init method: its arguments except the receiver and the injected context.Context
+func InitDeps(method reflect.Method) []reflect.Type {
+	first := 1
+	if AcceptsContext(method) {
+		first = 2
+	}
+
+	deps := make([]reflect.Type, 0, max(method.Type.NumIn()-first, 0))
+	for j := first; j < method.Type.NumIn(); j++ {
+		deps = append(deps, method.Type.In(j))
+	}
+
+	return deps
+}
+
 // Graph manages the set of services and their edges
 // type of the VerticesMap: directed
 type Graph struct {
-	// Map with vertices to have an easy access to it
-	vertices map[reflect.Type]*Vertex
+	// Map with vertices to have an easy access to it, the key is the plugin value, so instances of the same type are independent
+	vertices map[any]*Vertex
 	// List of all Vertices
 	topologicalOrder []*Vertex
+	// all added vertices (including removed) in the registration order
+	registered []*Vertex
+	// decorators names of the interface types, in the order of application
+	decorators map[reflect.Type][]string
 }
 
 // New initializes endure Graph
@@ -28,23 +74,36 @@ type Graph struct {
 // 2. ACYCLIC
 func New() *Graph {
 	return &Graph{
-		vertices:         make(map[reflect.Type]*Vertex),
+		vertices:         make(map[any]*Vertex),
 		topologicalOrder: make([]*Vertex, 0),
+		decorators:       make(map[reflect.Type][]string),
 	}
 }
 
 // HasVertex returns true or false if the vertex exists in the vertices map in the graph
 func (g *Graph) HasVertex(plugin any) bool {
-	tp := reflect.TypeOf(plugin)
-	_, ok := g.vertices[tp]
+	_, ok := g.vertices[plugin]
 	return ok
 }
 
-func (g *Graph) AddEdge(edgeType EdgeType, src, dest any) {
+// HasInstance returns true if the graph has a vertex with the same ID and instance name
+func (g *Graph) HasInstance(tp reflect.Type, name string) bool {
+	for _, v := range g.vertices {
+		if v.id == tp && v.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddEdge adds an edge from the src (provider) to the dest (consumer), via is the interface type which connects them
+func (g *Graph) AddEdge(edgeType EdgeType, src, dest any, via reflect.Type) {
 	e := &edge{
 		src:            src,
 		dest:           dest,
 		connectionType: edgeType,
+		via:            via,
 	}
 
 	s := g.VertexById(e.src)
@@ -55,9 +114,10 @@ func (g *Graph) AddEdge(edgeType EdgeType, src, dest any) {
 }
 
 func (g *Graph) VertexById(plugin any) *Vertex {
-	return g.vertices[reflect.TypeOf(plugin)]
+	return g.vertices[plugin]
 }
 
+// Vertices returns all vertices of the graph sorted by ID to keep the traversal deterministic
 func (g *Graph) Vertices() []*Vertex {
 	v := make([]*Vertex, 0, len(g.vertices))
 
@@ -65,6 +125,10 @@ func (g *Graph) Vertices() []*Vertex {
 		v = append(v, vrx)
 	}
 
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].String() < v[j].String()
+	})
+
 	return v
 }
 
@@ -72,53 +136,158 @@ func (g *Graph) TopologicalOrder() []*Vertex {
 	return g.topologicalOrder
 }
 
+// Registered returns all added vertices in the registration order, removed vertices are included
+func (g *Graph) Registered() []*Vertex {
+	return g.registered
+}
+
+// Edges returns the edges between the vertices of the graph in the topological order of their sources
+func (g *Graph) Edges() []*Hop {
+	var edges []*Hop
+	for _, v := range g.topologicalOrder {
+		for i := range v.edges {
+			src := g.VertexById(v.edges[i].src)
+			dest := g.VertexById(v.edges[i].dest)
+			// vertex was removed
+			if src == nil || dest == nil {
+				continue
+			}
+
+			edges = append(edges, &Hop{
+				Src:  src,
+				Dest: dest,
+				Via:  v.edges[i].via,
+				Kind: v.edges[i].connectionType,
+			})
+		}
+	}
+
+	return edges
+}
+
+// Dependents returns the vertex and all vertices which (transitively) depend on it, in the topological order
+func (g *Graph) Dependents(plugin any) []*Vertex {
+	seen := g.reachable(plugin)
+
+	res := make([]*Vertex, 0, len(seen))
+	for _, v := range g.topologicalOrder {
+		if _, ok := seen[v]; ok {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// Reachable returns the vertex and all vertices which (transitively) depend on it in the registration order
+// unlike the Dependents, vertices excluded from the topological order (e.g. by a cycle) are included
+func (g *Graph) Reachable(plugin any) []*Vertex {
+	seen := g.reachable(plugin)
+
+	res := make([]*Vertex, 0, len(seen))
+	for _, v := range g.registered {
+		if _, ok := seen[v]; ok {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+func (g *Graph) reachable(plugin any) map[*Vertex]struct{} {
+	root := g.VertexById(plugin)
+	if root == nil {
+		return nil
+	}
+
+	seen := map[*Vertex]struct{}{root: {}}
+	queue := []*Vertex{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		for i := range v.edges {
+			dest := g.VertexById(v.edges[i].dest)
+			if dest == nil {
+				continue
+			}
+
+			if _, ok := seen[dest]; ok {
+				continue
+			}
+
+			seen[dest] = struct{}{}
+			queue = append(queue, dest)
+		}
+	}
+
+	return seen
+}
+
 func (g *Graph) Clean() {
 	g.topologicalOrder = nil
 	g.vertices = nil
+	g.registered = nil
 }
 
 // AddVertex adds an vertex to the graph with its ID, value and meta information
 func (g *Graph) AddVertex(vertex any, weight uint) {
-	tp := reflect.TypeOf(vertex)
-	g.vertices[tp] = &Vertex{
-		id:     tp,
+	g.AddNamedVertex(vertex, "", weight)
+}
+
+// AddNamedVertex adds an instance of the plugin, name distinguishes instances of the same type
+func (g *Graph) AddNamedVertex(vertex any, name string, weight uint) {
+	g.AddTypedVertex(vertex, reflect.TypeOf(vertex), name, weight)
+}
+
+// AddTypedVertex adds a vertex with the explicit ID, e.g. the type returned by the constructor
+func (g *Graph) AddTypedVertex(vertex any, id reflect.Type, name string, weight uint) {
+	v := &Vertex{
+		id:     id,
+		name:   name,
 		value:  vertex,
 		weight: weight,
 		active: true,
 	}
+
+	g.vertices[vertex] = v
+	g.registered = append(g.registered, v)
 }
 
 func (g *Graph) Remove(plugin any) []*Vertex {
-	tp := reflect.TypeOf(plugin)
 	var deletedVertices []*Vertex
 
 	// remove the vertex from the graph
-	vertex, ok := g.vertices[tp]
+	vertex, ok := g.vertices[plugin]
 	if ok {
-		delete(g.vertices, tp)
+		delete(g.vertices, plugin)
 		deletedVertices = append(deletedVertices, vertex)
 		vertex.active = false
 	}
 
 	edges := vertex.edges
 	for i := range edges {
-		if _, ok := g.vertices[reflect.TypeOf(edges[i].dest)]; !ok {
+		if _, ok := g.vertices[edges[i].dest]; !ok {
 			continue
 		}
 
 		switch edges[i].connectionType {
 		case InitConnection:
 			p := edges[i].dest
-			initMethod, _ := reflect.TypeOf(p).MethodByName(InitMethodName)
+			initMethod, _ := InitMethod(p)
 
-			args := make([]reflect.Type, initMethod.Type.NumIn())
-			// receiver + other (should be other, since this is a dest vertex)
-			for j := range initMethod.Type.NumIn() {
-				args[j] = initMethod.Type.In(j)
-			}
+			args := InitDeps(initMethod)
+
+			// optional dependencies and slices (might be empty) don't need a replacement
+			optional := dep.OptionalTypes(p)
+			args = slices.DeleteFunc(args, func(tp reflect.Type) bool {
+				if tp.Kind() == reflect.Slice {
+					return true
+				}
 
-			// remove receiver
-			args = args[1:]
+				_, ok := optional[tp]
+				return ok
+			})
 		retry:
 			for _, v := range g.vertices {
 				if len(args) == 0 {
@@ -141,13 +310,13 @@ func (g *Graph) Remove(plugin any) []*Vertex {
 			}
 			// we found replacement
 			if len(args) == 0 {
-				return deletedVertices
+				continue
 			}
 
 			// we didn't find a replacement, mark the vertex as inactive
-			deletedVertices = append(deletedVertices, g.vertices[reflect.TypeOf(edges[i].dest)])
-			g.vertices[reflect.TypeOf(edges[i].dest)].active = false
-			delete(g.vertices, reflect.TypeOf(edges[i].dest))
+			deletedVertices = append(deletedVertices, g.vertices[edges[i].dest])
+			g.vertices[edges[i].dest].active = false
+			delete(g.vertices, edges[i].dest)
 		case CollectsConnection:
 			continue
 		}
@@ -180,7 +349,23 @@ func (g *Graph) Remove(plugin any) []*Vertex {
 	return deletedVertices
 }
 
+// AddDecorator records the decorator of the interface type, decorated edges are labeled in the dot graph
+func (g *Graph) AddDecorator(tp reflect.Type, name string) {
+	g.decorators[tp] = append(g.decorators[tp], name)
+}
+
+// Decorators returns the decorators of the interface type in the order of application
+func (g *Graph) Decorators(tp reflect.Type) []string {
+	return g.decorators[tp]
+}
+
+// WriteDotString writes the dot graph to the stderr
 func (g *Graph) WriteDotString() {
+	_, _ = fmt.Fprint(os.Stderr, g.DotString())
+}
+
+// DotString returns the graph in the dot format
+func (g *Graph) DotString() string {
 	var s strings.Builder
 	s.WriteString("digraph endure {\n")
 	s.WriteString("\trankdir=TB;\n")
@@ -189,15 +374,30 @@ func (g *Graph) WriteDotString() {
 	seenEdges := make(map[string]struct{})
 	for i := range g.topologicalOrder {
 		for j := range g.topologicalOrder[i].edges {
-			src := reflect.TypeOf(g.topologicalOrder[i].edges[j].src).String()
-			dest := reflect.TypeOf(g.topologicalOrder[i].edges[j].dest).String()
+			sv := g.VertexById(g.topologicalOrder[i].edges[j].src)
+			dv := g.VertexById(g.topologicalOrder[i].edges[j].dest)
+			// vertex was removed
+			if sv == nil || dv == nil {
+				continue
+			}
+
+			src := sv.String()
+			dest := dv.String()
 
 			if _, ok := seenEdges[src+dest]; !ok {
-				fmt.Fprintf(&s, "\t%q -> %q;\n", src, dest)
 				seenEdges[src+dest] = struct{}{}
+
+				via := g.topologicalOrder[i].edges[j].via
+				if via != nil && len(g.decorators[via]) > 0 {
+					fmt.Fprintf(&s, "\t%q -> %q [label=%q];\n", src, dest, via.String()+" decorated by "+strings.Join(g.decorators[via], ", "))
+					continue
+				}
+
+				fmt.Fprintf(&s, "\t%q -> %q;\n", src, dest)
 			}
 		}
 	}
 	s.WriteString("}\n")
-	_, _ = fmt.Fprint(os.Stderr, s.String())
+
+	return s.String()
 }
@@ -10,6 +10,10 @@ func (h *VertexHeap) Len() int {
 	return len(*h)
 }
 func (h *VertexHeap) Less(i, j int) bool {
+	if (*h)[i].weight == (*h)[j].weight {
+		return (*h)[i].String() < (*h)[j].String()
+	}
+
 	return (*h)[i].weight < (*h)[j].weight
 }
 
@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Hop is a single step of the dependency cycle: Dest receives the Via interface from the Src
+type Hop struct {
+	Src  *Vertex
+	Dest *Vertex
+	Via  reflect.Type
+	Kind EdgeType
+}
+
+// Cycle is an ordered closed path of hops, the Dest of the last hop is the Src of the first one
+type Cycle []*Hop
+
+// StronglyConnectedComponents returns all strongly connected components of the graph (Tarjan's algorithm)
+// Components are returned in a deterministic order, vertices inside the component are sorted by ID
+func (g *Graph) StronglyConnectedComponents() [][]*Vertex {
+	vertices := g.Vertices()
+
+	index := 0
+	indices := make(map[*Vertex]int, len(vertices))
+	lowlink := make(map[*Vertex]int, len(vertices))
+	onStack := make(map[*Vertex]bool, len(vertices))
+	stack := make([]*Vertex, 0, len(vertices))
+	var components [][]*Vertex
+
+	var connect func(v *Vertex)
+	connect = func(v *Vertex) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for i := range v.edges {
+			w := g.VertexById(v.edges[i].dest)
+			if w == nil {
+				continue
+			}
+
+			if _, ok := indices[w]; !ok {
+				connect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		// v is the root of the component
+		if lowlink[v] == indices[v] {
+			var component []*Vertex
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+
+			sort.Slice(component, func(i, j int) bool {
+				return component[i].String() < component[j].String()
+			})
+			components = append(components, component)
+		}
+	}
+
+	for _, v := range vertices {
+		if _, ok := indices[v]; !ok {
+			connect(v)
+		}
+	}
+
+	return components
+}
+
+// Cycles returns the dependency cycles of the graph
+// Every vertex of the strongly connected component (with more than one vertex) is covered by at least one returned cycle
+func (g *Graph) Cycles() []Cycle {
+	var cycles []Cycle
+
+	for _, component := range g.StronglyConnectedComponents() {
+		if len(component) < 2 {
+			continue
+		}
+
+		inComponent := make(map[*Vertex]struct{}, len(component))
+		for _, v := range component {
+			inComponent[v] = struct{}{}
+		}
+
+		covered := make(map[*Vertex]struct{}, len(component))
+		for _, v := range component {
+			if _, ok := covered[v]; ok {
+				continue
+			}
+
+			cycle := g.shortestCycle(v, inComponent)
+			for _, h := range cycle {
+				covered[h.Src] = struct{}{}
+			}
+
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	return cycles
+}
+
+// shortestCycle searches (BFS) for the shortest path from the start vertex back to itself inside the component
+func (g *Graph) shortestCycle(start *Vertex, component map[*Vertex]struct{}) Cycle {
+	// hop used to reach the vertex
+	via := make(map[*Vertex]*Hop, len(component))
+	queue := []*Vertex{start}
+
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		for i := range v.edges {
+			w := g.VertexById(v.edges[i].dest)
+			if w == nil {
+				continue
+			}
+
+			if _, ok := component[w]; !ok {
+				continue
+			}
+
+			hop := &Hop{
+				Src:  v,
+				Dest: w,
+				Via:  v.edges[i].via,
+				Kind: v.edges[i].connectionType,
+			}
+
+			if w == start {
+				// restore the path
+				cycle := Cycle{hop}
+				for p := v; p != start; p = via[p].Src {
+					cycle = append(cycle, via[p])
+				}
+
+				// reverse, path should start from the start vertex
+				for l, r := 0, len(cycle)-1; l < r; l, r = l+1, r-1 {
+					cycle[l], cycle[r] = cycle[r], cycle[l]
+				}
+
+				return cycle
+			}
+
+			if _, ok := via[w]; ok {
+				continue
+			}
+
+			via[w] = hop
+			queue = append(queue, w)
+		}
+	}
+
+	return nil
+}
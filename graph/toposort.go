@@ -1,9 +1,13 @@
 package graph
 
+import (
+	"slices"
+)
+
 func (g *Graph) TopologicalSort() {
 	heap := &VertexHeap{}
 
-	for _, v := range g.vertices {
+	for _, v := range g.Vertices() {
 		if v.indegree == 0 {
 			heap.Push(v)
 		}
@@ -34,3 +38,80 @@ func (g *Graph) TopologicalSort() {
 		}
 	}
 }
+
+// Append adds the vertex to the end of the topological order, the vertex should depend only on the already sorted vertices
+func (g *Graph) Append(vertex *Vertex) {
+	g.topologicalOrder = append(g.topologicalOrder, vertex)
+}
+
+// TopologicalLevels splits the topological order into levels (antichains)
+// every vertex is placed on the level right after the deepest of its dependencies, so vertices of the same level don't depend on each other
+// vertices within the level keep their topological order
+func (g *Graph) TopologicalLevels() [][]*Vertex {
+	level := make(map[*Vertex]int, len(g.topologicalOrder))
+	for _, v := range g.topologicalOrder {
+		for i := range v.edges {
+			dest := g.VertexById(v.edges[i].dest)
+			if dest == nil {
+				continue
+			}
+
+			level[dest] = max(level[dest], level[v]+1)
+		}
+	}
+
+	var levels [][]*Vertex
+	for _, v := range g.topologicalOrder {
+		for len(levels) <= level[v] {
+			levels = append(levels, nil)
+		}
+
+		levels[level[v]] = append(levels[level[v]], v)
+	}
+
+	return levels
+}
+
+// CriticalPath returns the most expensive dependency chain (in the topological order) and its total cost
+// cost is the cost of the vertex itself, the chain cost is the sum of its vertices costs
+func (g *Graph) CriticalPath(cost func(v *Vertex) int64) ([]*Vertex, int64) {
+	total := make(map[*Vertex]int64, len(g.topologicalOrder))
+	prev := make(map[*Vertex]*Vertex, len(g.topologicalOrder))
+
+	var last *Vertex
+	for _, v := range g.topologicalOrder {
+		// all dependencies of the vertex are already processed, prev is the most expensive one
+		total[v] = cost(v)
+		if p, ok := prev[v]; ok {
+			total[v] += total[p]
+		}
+
+		if last == nil || total[v] > total[last] {
+			last = v
+		}
+
+		for i := range v.edges {
+			dest := g.VertexById(v.edges[i].dest)
+			if dest == nil {
+				continue
+			}
+
+			if _, ok := prev[dest]; !ok || total[v] > total[prev[dest]] {
+				prev[dest] = v
+			}
+		}
+	}
+
+	if last == nil {
+		return nil, 0
+	}
+
+	var path []*Vertex
+	for v := last; v != nil; v = prev[v] {
+		path = append(path, v)
+	}
+
+	slices.Reverse(path)
+
+	return path, total[last]
+}
@@ -10,6 +10,8 @@ import (
 type Vertex struct {
 	// ID of the vertex, currently string representation of the structure fn
 	id reflect.Type
+	// name of the plugin instance, empty for the single instance of the type
+	name string
 
 	// value is a plugin itself
 	value any
@@ -28,6 +30,20 @@ func (v *Vertex) ID() reflect.Type {
 	return v.id
 }
 
+// Name returns the instance name of the plugin, empty if the plugin was registered without a name
+func (v *Vertex) Name() string {
+	return v.name
+}
+
+// String returns the vertex identity: the plugin type, followed by the instance name when set, e.g.: *redis.Plugin#cache
+func (v *Vertex) String() string {
+	if v.name == "" {
+		return v.id.String()
+	}
+
+	return v.id.String() + "#" + v.name
+}
+
 func (v *Vertex) Plugin() any {
 	return v.value
 }
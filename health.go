@@ -0,0 +1,149 @@
+package endure
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Status is the health or readiness status reported by the plugin
+type Status struct {
+	// OK is true when the plugin is healthy (ready)
+	OK bool `json:"ok"`
+	// Details is an optional human-readable description of the status
+	Details string `json:"details,omitempty"`
+}
+
+// PluginStatus is the status of the particular plugin
+type PluginStatus struct {
+	Plugin string `json:"plugin"`
+	Status
+}
+
+// HealthReport is the aggregated status of the plugins in the topological order
+type HealthReport struct {
+	// OK is true when all plugins are OK
+	OK      bool            `json:"ok"`
+	Plugins []*PluginStatus `json:"plugins"`
+}
+
+type (
+	// Readiness is optional to implement, reports whether the plugin is ready to accept the work
+	Readiness interface {
+		Ready() *Status
+	}
+
+	// Liveness is optional to implement, reports whether the plugin is alive
+	Liveness interface {
+		Health() *Status
+	}
+)
+
+// Health aggregates the Liveness statuses of the active plugins
+func (e *Endure) Health() *HealthReport {
+	return e.healthReport(func(values []any) (*Status, bool) {
+		if val, ok := valueAs[Liveness](values); ok {
+			return val.Health(), true
+		}
+
+		return nil, false
+	})
+}
+
+// Ready aggregates the Readiness statuses of the active plugins, the container is not ready until it is served
+func (e *Endure) Ready() *HealthReport {
+	report := e.healthReport(func(values []any) (*Status, bool) {
+		if val, ok := valueAs[Readiness](values); ok {
+			return val.Ready(), true
+		}
+
+		return nil, false
+	})
+
+	if !e.serving.Load() {
+		report.OK = false
+	}
+
+	return report
+}
+
+// probedPlugin is the snapshot of the active plugin for the probes
+type probedPlugin struct {
+	id string
+	// effective plugin values, see the pluginValues
+	values []any
+}
+
+// probedPlugins returns the active plugins in the topological order
+// the container is locked during the Init, Stop and restarts, the probes should not wait for them, so the last snapshot is returned
+func (e *Endure) probedPlugins() []*probedPlugin {
+	if !e.mu.TryRLock() {
+		if plugins := e.probed.Load(); plugins != nil {
+			return *plugins
+		}
+
+		return nil
+	}
+	defer e.mu.RUnlock()
+
+	order := e.graph.TopologicalOrder()
+	plugins := make([]*probedPlugin, 0, len(order))
+	for i := range order {
+		if order[i].IsActive() {
+			plugins = append(plugins, &probedPlugin{id: order[i].String(), values: pluginValues(order[i])})
+		}
+	}
+
+	e.probed.Store(&plugins)
+
+	return plugins
+}
+
+func (e *Endure) healthReport(check func(values []any) (*Status, bool)) *HealthReport {
+	report := &HealthReport{
+		OK:      true,
+		Plugins: make([]*PluginStatus, 0, 2),
+	}
+
+	plugins := e.probedPlugins()
+	for i := range plugins {
+		st, ok := check(plugins[i].values)
+		if !ok {
+			continue
+		}
+
+		if st == nil {
+			st = &Status{Details: "plugin returned nil status"}
+		}
+
+		if !st.OK {
+			report.OK = false
+		}
+
+		report.Plugins = append(report.Plugins, &PluginStatus{
+			Plugin: plugins[i].id,
+			Status: *st,
+		})
+	}
+
+	return report
+}
+
+// healthHandler writes the report as a JSON, responds with 503 when the report is not OK
+func healthHandler(report func() *HealthReport) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		rep := report()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !rep.OK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(rep)
+	}
+}
+
+// handleProbes adds the /health and /ready endpoints to the mux
+func (e *Endure) handleProbes(mux *http.ServeMux) {
+	mux.HandleFunc("/health", healthHandler(e.Health))
+	mux.HandleFunc("/ready", healthHandler(e.Ready))
+}
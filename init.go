@@ -1,12 +1,32 @@
 package endure
 
 import (
+	"context"
 	"reflect"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/errors"
 	"go.uber.org/zap"
 )
 
+// initCall is the prepared call of the plugin's Init method
+type initCall struct {
+	vertex *graph.Vertex
+	method reflect.Method
+	// receiver + resolved dependencies (without the context)
+	in []reflect.Value
+	// context.Context should be injected as the first argument
+	withContext bool
+	// timeout is shared by the providers and the Init call
+	timeout time.Duration
+	// time spent by the providers, the rest of the timeout is left for the Init
+	provided time.Duration
+}
+
 func (e *Endure) init() error {
 	/*
 		topological order
@@ -17,140 +37,415 @@ func (e *Endure) init() error {
 		return errors.E(errors.Str("error occurred, nothing to run"))
 	}
 
+	ctx, span := e.startPhase(context.Background(), "init")
+
+	var err error
+	switch e.parallelInit {
+	case true:
+		err = e.initLevels(ctx)
+	case false:
+		err = e.initSequential(ctx, vertices)
+	}
+
+	endSpan(span, err)
+	if err != nil {
+		// fatal error, clean the graph
+		e.graph.Clean()
+		return err
+	}
+
+	inactive := 0
+	for i := range vertices {
+		if !vertices[i].IsActive() {
+			inactive++
+		}
+	}
+
+	if inactive == len(vertices) {
+		return errors.E(errors.Str("All plugins are disabled, nothing to serve"))
+	}
+
+	return nil
+}
+
+// initSequential calls Init methods one by one in the topological order
+func (e *Endure) initSequential(ctx context.Context, vertices []*graph.Vertex) error {
 	for i := range vertices {
 		if !vertices[i].IsActive() {
 			continue
 		}
 
-		initMethod, _ := reflect.TypeOf(vertices[i].Plugin()).MethodByName(InitMethodName)
-
-		args := make([]reflect.Type, initMethod.Type.NumIn())
-		for j := range initMethod.Type.NumIn() {
-			args[j] = initMethod.Type.In(j)
-		}
-
-		var inVals []reflect.Value
-		inVals = append(inVals, reflect.ValueOf(vertices[i].Plugin()))
-		// has deps if > 1
-		if len(args) > 1 {
-			// exclude first arg (its receiver)
-			arg := args[1:]
-			for j := range arg {
-				plugin := e.registar.ImplementsExcept(arg[j], vertices[i].Plugin())
-				if len(plugin) == 0 {
-					del := e.graph.Remove(vertices[i].Plugin())
-					for k := range del {
-						e.registar.Remove(del[k].Plugin())
-						e.log.Debug(
-							"plugin disabled, not enough Init dependencies",
-							zap.String("name", del[k].ID().String()),
-						)
-					}
+		call, err := e.prepareInit(ctx, vertices[i])
+		if err != nil {
+			return err
+		}
 
-					continue
-				}
+		// plugin was disabled
+		if call == nil {
+			continue
+		}
+
+		ret, err := e.callInit(ctx, call)
+		if err != nil {
+			return err
+		}
 
-				// check if the provided plugin dep has a method
-				// existence of the method indicates that the dep provided by this plugin should be obtained via the method call
-				switch plugin[0].Method() == "" {
-				// we don't have a method, that means, plugin itself implements the dep
-				case true:
-					inVals = append(inVals, reflect.ValueOf(plugin[0].Plugin()))
+		err = e.finishInit(call, ret)
+		if err != nil {
+			return err
+		}
+	}
 
-					// we have a method, thus we need to get the value, because previous plugin have registered it's provided deps
-				case false:
-					value, ok := e.registar.TypeValue(plugin[0].Plugin(), arg[j])
-					if !ok {
-						return errors.E("this is likely a bug, nil value from the implements. Value should be initialized due to the topological order")
-					}
-					inVals = append(inVals, value)
-				}
+	return nil
+}
+
+// initLevels calls Init methods of the plugins within the same topological level concurrently
+// levels are processed one by one, results are processed in the topological order of the level
+func (e *Endure) initLevels(ctx context.Context) error {
+	const op = errors.Op("endure_init_levels")
+	levels := e.graph.TopologicalLevels()
+
+	for l := range levels {
+		// prepare calls sequentially, registar is not safe for the concurrent use
+		calls := make([]*initCall, 0, len(levels[l]))
+		for i := range levels[l] {
+			if !levels[l][i].IsActive() {
+				continue
 			}
+
+			call, err := e.prepareInit(ctx, levels[l][i])
+			if err != nil {
+				return err
+			}
+
+			if call == nil {
+				continue
+			}
+
+			calls = append(calls, call)
 		}
 
-		ret := initMethod.Func.Call(inVals)
-		if len(ret) > 1 {
-			// fatal error, clean the graph
-			e.graph.Clean()
-			return errors.E("Init function should return only error, `Init(args) error {}`")
+		e.log.Debug("initializing level", zap.Int("level", l), zap.Int("plugins", len(calls)))
+
+		rets := make([][]reflect.Value, len(calls))
+		errs := make([]error, len(calls))
+		sem := make(chan struct{}, e.initWorkers)
+		wg := &sync.WaitGroup{}
+
+		for i := range calls {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					// the panic could not be recovered by the caller of the Init from the worker
+					if r := recover(); r != nil {
+						errs[i] = initPanic(op, calls[i].vertex, r)
+					}
+
+					<-sem
+					wg.Done()
+				}()
+
+				rets[i], errs[i] = e.callInit(ctx, calls[i])
+			}(i)
+		}
+
+		wg.Wait()
+
+		// process results in order to keep them deterministic, first fatal error stops the remaining levels
+		for i := range calls {
+			if errs[i] != nil {
+				return errs[i]
+			}
+
+			err := e.finishInit(calls[i], rets[i])
+			if err != nil {
+				return err
+			}
 		}
+	}
+
+	return nil
+}
+
+// prepareInit resolves Init dependencies of the vertex
+// nil call returned when the vertex was disabled because of missing dependencies
+func (e *Endure) prepareInit(ctx context.Context, vertex *graph.Vertex) (*initCall, error) {
+	initMethod, _ := graph.InitMethod(vertex.Plugin())
+
+	call := &initCall{
+		vertex:      vertex,
+		method:      initMethod,
+		withContext: graph.AcceptsContext(initMethod),
+		timeout:     e.initTimeout,
+	}
 
-		if ret[0].Type() != reflect.TypeFor[error]() {
-			// fatal error, clean the graph
-			e.graph.Clean()
-			return errors.E("Init function return type should be the error")
+	if val, ok := vertex.Plugin().(TimedInit); ok {
+		call.timeout = val.InitTimeout()
+	}
+
+	call.in = append(call.in, reflect.ValueOf(vertex.Plugin()))
+	// receiver and context are not the dependencies
+	arg := graph.InitDeps(initMethod)
+	if len(arg) > 0 {
+		// providers share the Init timeout of the consumer
+		if call.timeout > 0 {
+			start := time.Now()
+			defer func() {
+				call.provided = time.Since(start)
+			}()
+
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, call.timeout)
+			defer cancel()
 		}
 
-		if ret[0].Interface() != nil {
-			// may panic here?
-			if _, ok := ret[0].Interface().(error); !ok {
-				// fatal error, clean the graph
-				e.graph.Clean()
-				return errors.E("Init function should return only error, `Init(args) error {}`")
+		optional := dep.OptionalTypes(vertex.Plugin())
+		qualifiers := dep.QualifiedTypes(vertex.Plugin())
+		for j := range arg {
+			// all implementations, ordered by weight
+			if isInterfaceSlice(arg[j]) {
+				impl := e.registar.ImplementsExcept(arg[j].Elem(), vertex.Plugin())
+				values := reflect.MakeSlice(arg[j], 0, len(impl))
+				for k := range impl {
+					if impl[k].Method() == "" {
+						values = reflect.Append(values, e.decorate(arg[j].Elem(), reflect.ValueOf(impl[k].Plugin())))
+						continue
+					}
+
+					value, ok, err := e.registar.TypeValue(ctx, impl[k].Plugin(), arg[j].Elem(), impl[k].Method(), impl[k].Name())
+					if err != nil {
+						return nil, e.providerError(impl[k].Plugin(), impl[k].Method(), vertex, arg[j].Elem(), err)
+					}
+					if !ok {
+						return nil, errors.E("this is likely a bug, nil value from the implements. Value should be initialized due to the topological order")
+					}
+					values = reflect.Append(values, e.decorate(arg[j].Elem(), value))
+				}
+
+				call.in = append(call.in, values)
+				continue
 			}
 
-			if errors.Is(errors.Disabled, ret[0].Interface().(error)) {
-				e.log.Debug(
-					"plugin disabled",
-					zap.String("name", vertices[i].ID().String()),
-				)
-				// delete vertex and continue
-				plugins := e.graph.Remove(vertices[i].Plugin())
+			plugin := e.registar.ImplementsNamed(arg[j], vertex.Plugin(), qualifiers[arg[j]])
+			if _, ok := optional[arg[j]]; ok && len(plugin) == 0 {
+				// nil interface for the missing optional dependency
+				call.in = append(call.in, reflect.Zero(arg[j]))
+				continue
+			}
 
-				for j := range plugins {
+			if len(plugin) == 0 {
+				del := e.graph.Remove(vertex.Plugin())
+				for k := range del {
+					e.registar.Remove(del[k].Plugin())
 					e.log.Debug(
-						"destination plugin disabled because root was disabled",
-						zap.String("name", plugins[j].ID().String()),
+						"plugin disabled, not enough Init dependencies",
+						zap.String("name", del[k].String()),
 					)
-					e.registar.Remove(plugins[j].Plugin())
 				}
-				continue
+				e.emitDisabled(del, &DisableReason{Kind: DisabledMissingDependency, Missing: []string{arg[j].String()}})
+
+				return nil, nil
 			}
 
-			// fatal error, clean the graph
-			e.graph.Clean()
-			return ret[0].Interface().(error)
+			// check if the provided plugin dep has a method
+			// existence of the method indicates that the dep provided by this plugin should be obtained via the method call
+			switch plugin[0].Method() == "" {
+			// we don't have a method, that means, plugin itself implements the dep
+			case true:
+				call.in = append(call.in, e.decorate(arg[j], reflect.ValueOf(plugin[0].Plugin())))
+
+				// we have a method, thus we need to get the value, because previous plugin have registered it's provided deps
+			case false:
+				value, ok, err := e.registar.TypeValue(ctx, plugin[0].Plugin(), arg[j], plugin[0].Method(), plugin[0].Name())
+				if err != nil {
+					return nil, e.providerError(plugin[0].Plugin(), plugin[0].Method(), vertex, arg[j], err)
+				}
+				if !ok {
+					return nil, errors.E("this is likely a bug, nil value from the implements. Value should be initialized due to the topological order")
+				}
+				call.in = append(call.in, e.decorate(arg[j], value))
+			}
 		}
+	}
 
-		// add vertex itself
-		vrtx := vertices[i].Plugin()
-		e.registar.Update(vrtx, reflect.TypeOf(vrtx), func() reflect.Value {
-			return reflect.ValueOf(vrtx)
-		})
+	return call, nil
+}
 
-		if provider, ok := vertices[i].Plugin().(Provider); ok {
-			out := provider.Provides()
-			for j := range out {
-				providesMethod, okk := reflect.TypeOf(vertices[i].Plugin()).MethodByName(out[j].Method)
-				if !okk {
-					e.log.Warn("registered method doesn't exists ??")
-					continue
-				}
+// providerError is the error of the provider method, which failed to provide the value of the tp type to the consumer
+func (e *Endure) providerError(provider any, method string, consumer *graph.Vertex, tp reflect.Type, err error) error {
+	const op = errors.Op("endure_provide")
 
-				tp := out[j].Type
-				pl := vertices[i].Plugin()
-				in := []reflect.Value{inVals[0]}
-				e.registar.Update(pl, tp, func() reflect.Value {
-					vals := providesMethod.Func.Call(in)
-					if len(vals) != 1 {
-						panic("provides method should provide only 1 arg - structure")
-					}
+	src := e.graph.VertexById(provider).String()
+	e.log.Error(
+		"provider failed",
+		zap.String("provider", src),
+		zap.String("method", method),
+		zap.String("consumer", consumer.String()),
+		zap.Error(err),
+	)
 
-					return vals[0]
-				})
+	return errors.E(op, errors.Init, errors.Errorf("plugin %s (%s) failed to provide %s for the plugin %s: %v", src, method, tp.String(), consumer.String(), err))
+}
+
+// callInit calls the Init method within its span and notifies the observers
+func (e *Endure) callInit(ctx context.Context, call *initCall) ([]reflect.Value, error) {
+	id := call.vertex.String()
+	e.emit(&Event{Type: EventInitStarted, Plugin: id})
+
+	ctx, span := e.startCall(ctx, call.vertex, InitMethodName)
+
+	start := time.Now()
+	ret, err := e.invokeInit(ctx, call)
+
+	ev := &Event{Type: EventInitFinished, Plugin: id, Duration: time.Since(start), Error: err}
+	e.timings.record(call.vertex, phaseInit, ev.Duration)
+	if err == nil && len(ret) == 1 {
+		if rerr, ok := ret[0].Interface().(error); ok {
+			ev.Error = rerr
+		}
+	}
+	e.emit(ev)
+	endSpan(span, ev.Error)
+
+	return ret, err
+}
+
+// invokeInit calls the Init method, when the timeout is set, waits for the Init for the rest of the timeout left by the providers
+// the timeout starts when the Init is invoked, so the time spent waiting for the ParallelInit worker is not counted
+// NOTE: a plugin which ignores the context could not be interrupted, its goroutine is abandoned
+func (e *Endure) invokeInit(ctx context.Context, call *initCall) ([]reflect.Value, error) {
+	const op = errors.Op("endure_call_init")
+
+	if call.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, call.timeout-call.provided)
+		defer cancel()
+	}
+
+	in := call.in
+	if call.withContext {
+		in = slices.Insert(slices.Clone(call.in), 1, reflect.ValueOf(ctx))
+	}
+
+	if call.timeout <= 0 {
+		return call.method.Func.Call(in), nil
+	}
+
+	type result struct {
+		ret []reflect.Value
+		err error
+	}
+
+	done := make(chan *result, 1)
+	go func() {
+		// the panic could not be recovered by the caller of the Init from this goroutine
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &result{err: initPanic(op, call.vertex, r)}
 			}
+		}()
+
+		done <- &result{ret: call.method.Func.Call(in)}
+	}()
+
+	select {
+	case res := <-done:
+		return res.ret, res.err
+	case <-ctx.Done():
+		id := call.vertex.String()
+		e.log.Error("plugin Init timeout exceeded", zap.String("plugin", id), zap.Duration("timeout", call.timeout))
+		return nil, errors.E(op, errors.TimeOut, errors.Errorf("plugin %s did not finish Init within %s", id, call.timeout))
+	}
+}
+
+// initPanic is the error of the plugin, which panicked in the Init
+func initPanic(op errors.Op, vertex *graph.Vertex, r any) error {
+	return errors.E(op, errors.Init, errors.Errorf("plugin %s panicked in Init: %v", vertex.String(), r))
+}
+
+// finishInit checks the Init result and registers the values provided by the vertex
+func (e *Endure) finishInit(call *initCall, ret []reflect.Value) error {
+	vertex := call.vertex
+
+	if len(ret) > 1 {
+		return errors.E("Init function should return only error, `Init(args) error {}`")
+	}
+
+	if ret[0].Type() != reflect.TypeFor[error]() {
+		return errors.E("Init function return type should be the error")
+	}
+
+	if ret[0].Interface() != nil {
+		// may panic here?
+		if _, ok := ret[0].Interface().(error); !ok {
+			return errors.E("Init function should return only error, `Init(args) error {}`")
+		}
+
+		if errors.Is(errors.Disabled, ret[0].Interface().(error)) {
+			e.log.Debug(
+				"plugin disabled",
+				zap.String("name", vertex.String()),
+			)
+			// delete vertex and continue
+			plugins := e.graph.Remove(vertex.Plugin())
+
+			for j := range plugins {
+				e.log.Debug(
+					"destination plugin disabled because root was disabled",
+					zap.String("name", plugins[j].String()),
+				)
+				e.registar.Remove(plugins[j].Plugin())
+			}
+			e.emitDisabled(plugins, &DisableReason{Kind: DisabledByInit})
+
+			return nil
 		}
+
+		return ret[0].Interface().(error)
 	}
 
-	inactive := 0
-	for i := range vertices {
-		if !vertices[i].IsActive() {
-			inactive++
+	// add vertex itself
+	vrtx := vertex.Plugin()
+	e.registar.Update(vrtx, reflect.TypeOf(vrtx), "", func(context.Context) (reflect.Value, error) {
+		return reflect.ValueOf(vrtx), nil
+	})
+
+	// values returned by the constructor
+	if fp, ok := vertex.Plugin().(*funcPlugin); ok {
+		for j := range fp.out {
+			val := fp.values[j]
+			e.registar.Update(fp, fp.out[j], fp.name, func(context.Context) (reflect.Value, error) {
+				return val, nil
+			})
 		}
 	}
 
-	if inactive == len(vertices) {
-		return errors.E(errors.Str("All plugins are disabled, nothing to serve"))
+	if provider, ok := vertex.Plugin().(Provider); ok {
+		out := provider.Provides()
+		for j := range out {
+			// typed provider, bound to the function itself
+			if out[j].Func.IsValid() {
+				fn := out[j].Func
+				e.registar.Update(vertex.Plugin(), out[j].Type, out[j].Method, func(ctx context.Context) (reflect.Value, error) {
+					return provide(ctx, fn, nil)
+				})
+				continue
+			}
+
+			providesMethod, okk := reflect.TypeOf(vertex.Plugin()).MethodByName(out[j].Method)
+			if !okk {
+				e.log.Warn("registered method doesn't exists ??")
+				continue
+			}
+
+			tp := out[j].Type
+			pl := vertex.Plugin()
+			in := []reflect.Value{call.in[0]}
+			e.registar.Update(pl, tp, out[j].Method, func(ctx context.Context) (reflect.Value, error) {
+				return provide(ctx, providesMethod.Func, in)
+			})
+		}
 	}
 
 	return nil
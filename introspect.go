@@ -0,0 +1,250 @@
+package endure
+
+import (
+	"context"
+	stderr "errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/errors"
+	"go.uber.org/zap"
+)
+
+// PluginState is the state of the registered plugin
+type PluginState struct {
+	Plugin string `json:"plugin"`
+	Active bool   `json:"active"`
+	// Reason why the plugin was disabled
+	Reason *DisableReason `json:"reason,omitempty"`
+	// Stopped is true when the plugin was stopped via the admin control endpoint
+	Stopped bool `json:"stopped,omitempty"`
+	Weight  uint `json:"weight"`
+	// Provides is the types provided by the plugin, except the plugin type itself
+	Provides []string `json:"provides,omitempty"`
+	// LastError is the last error sent by the plugin to the Serve channel and forwarded to the user as the Result
+	LastError string `json:"last_error,omitempty"`
+}
+
+// EdgeState is the dependency edge: Dest receives the Via interface from the Src
+type EdgeState struct {
+	Src  string         `json:"src"`
+	Dest string         `json:"dest"`
+	Type graph.EdgeType `json:"type"`
+	Via  string         `json:"via,omitempty"`
+}
+
+// State is the snapshot of the container
+type State struct {
+	// Plugins in the topological order, followed by the plugins excluded from it (disabled during the edges resolution or by the cycle)
+	Plugins []*PluginState `json:"plugins"`
+	// Order is the topological order of the active plugins
+	Order []string     `json:"order"`
+	Edges []*EdgeState `json:"edges"`
+}
+
+// lastErrors holds the last error forwarded to the user per plugin
+type lastErrors struct {
+	mu   sync.Mutex
+	errs map[*graph.Vertex]error
+}
+
+func (l *lastErrors) set(vertex *graph.Vertex, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.errs[vertex] = err
+}
+
+func (l *lastErrors) get(vertex *graph.Vertex) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.errs[vertex]
+}
+
+// State returns the snapshot of the container: plugins with their states, the topological order and the edges
+func (e *Endure) State() *State {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	state := &State{
+		Plugins: e.pluginStates(),
+		Order:   make([]string, 0, len(e.graph.TopologicalOrder())),
+	}
+
+	for _, v := range e.graph.TopologicalOrder() {
+		if v.IsActive() {
+			state.Order = append(state.Order, v.String())
+		}
+	}
+
+	edges := e.graph.Edges()
+	state.Edges = make([]*EdgeState, 0, len(edges))
+	for i := range edges {
+		es := &EdgeState{
+			Src:  edges[i].Src.String(),
+			Dest: edges[i].Dest.String(),
+			Type: edges[i].Kind,
+		}
+
+		if edges[i].Via != nil {
+			es.Via = edges[i].Via.String()
+		}
+
+		state.Edges = append(state.Edges, es)
+	}
+
+	return state
+}
+
+// PluginStates returns the states of all registered plugins (including disabled), see the State for the order
+func (e *Endure) PluginStates() []*PluginState {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.pluginStates()
+}
+
+func (e *Endure) pluginStates() []*PluginState {
+	order := e.graph.TopologicalOrder()
+	inOrder := make(map[*graph.Vertex]struct{}, len(order))
+	for i := range order {
+		inOrder[order[i]] = struct{}{}
+	}
+
+	// plugins excluded from the topological order are listed in the registration order
+	vertices := slices.Clone(order)
+	for _, v := range e.graph.Registered() {
+		if _, ok := inOrder[v]; !ok {
+			vertices = append(vertices, v)
+		}
+	}
+
+	states := make([]*PluginState, 0, len(vertices))
+	for _, v := range vertices {
+		ps := &PluginState{
+			Plugin: v.String(),
+			Active: v.IsActive(),
+			Reason: e.disabled[v],
+			Weight: v.Weight(),
+		}
+
+		// plugin in a cycle is active, but excluded from the topological order after the Init
+		if ps.Reason != nil {
+			ps.Active = false
+		}
+
+		if _, ok := e.halted[v]; ok {
+			ps.Stopped = true
+		}
+
+		for _, tp := range e.registar.Provides(v.Plugin()) {
+			ps.Provides = append(ps.Provides, tp.String())
+		}
+
+		if err := e.lastErrors.get(v); err != nil {
+			ps.LastError = err.Error()
+		}
+
+		states = append(states, ps)
+	}
+
+	return states
+}
+
+// activeVertex returns the active vertex by its ID (see graph.Vertex.String), nil if not found
+func (e *Endure) activeVertex(id string) *graph.Vertex {
+	for _, v := range e.graph.TopologicalOrder() {
+		if v.IsActive() && v.String() == id {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// stopPlugin stops the served plugin and its dependents in the reverse topological order, the stopped plugins are skipped on the Stop
+func (e *Endure) stopPlugin(id string) error {
+	const op = errors.Op("endure_stop_plugin")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	vertex := e.activeVertex(id)
+	if vertex == nil {
+		return errors.E(op, errors.Errorf("plugin %s is not found", id))
+	}
+
+	if !e.serving.Load() {
+		return errors.E(op, errors.Errorf("plugin %s is not served", id))
+	}
+
+	ctx, span := e.startPhase(context.Background(), "stop_plugin")
+	defer span.End()
+
+	var errs []error
+	for _, v := range slices.Backward(e.graph.Dependents(vertex.Plugin())) {
+		if _, ok := e.halted[v]; ok {
+			continue
+		}
+
+		e.stopPoller(v)
+		e.uncollect(v)
+
+		res := e.stopLevel(ctx, []*graph.Vertex{v})
+		for i := range res {
+			if res[i].Error != nil {
+				errs = append(errs, res[i].Error)
+			}
+		}
+
+		e.halted[v] = struct{}{}
+	}
+
+	e.log.Info("plugin stopped via the admin server", zap.String("plugin", id))
+
+	if len(errs) > 0 {
+		return errors.E(op, stderr.Join(errs...))
+	}
+
+	return nil
+}
+
+// restartPlugin restarts the plugin and its dependents (including the stopped ones)
+func (e *Endure) restartPlugin(id string) error {
+	const op = errors.Op("endure_restart_plugin")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	vertex := e.activeVertex(id)
+	if vertex == nil {
+		return errors.E(op, errors.Errorf("plugin %s is not found", id))
+	}
+
+	if !e.serving.Load() {
+		return errors.E(op, errors.Errorf("plugin %s is not served", id))
+	}
+
+	err := e.restart(vertex, true)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	e.log.Info("plugin restarted via the admin server", zap.String("plugin", id))
+
+	return nil
+}
+
+// controlHandler calls the control function with the plugin ID from the path, the '#' of the instance name should be escaped
+func controlHandler(control func(id string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := control(r.PathValue("plugin"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogCore is the zap core which routes entries to the slog.Handler
+type slogCore struct {
+	handler slog.Handler
+}
+
+// FromSlogHandler builds zap logger on top of the slog.Handler, level filtering is up to the handler
+func FromSlogHandler(handler slog.Handler) *zap.Logger {
+	return zap.New(&slogCore{handler: handler})
+}
+
+func (c *slogCore) Enabled(level zapcore.Level) bool {
+	return c.handler.Enabled(context.Background(), slogLevel(level))
+}
+
+func (c *slogCore) With(fields []zapcore.Field) zapcore.Core {
+	return &slogCore{
+		handler: c.handler.WithAttrs(attrs(fields)),
+	}
+}
+
+func (c *slogCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *slogCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	r := slog.NewRecord(entry.Time, slogLevel(entry.Level), entry.Message, 0)
+	if entry.LoggerName != "" {
+		r.AddAttrs(slog.String("logger", entry.LoggerName))
+	}
+
+	r.AddAttrs(attrs(fields)...)
+
+	return c.handler.Handle(context.Background(), r)
+}
+
+func (c *slogCore) Sync() error {
+	return nil
+}
+
+// attrs converts zap fields to the slog attributes, keeping the fields order
+// a field might add several keys (e.g. zap.Error adds errorVerbose), they are sorted to keep the output stable
+func attrs(fields []zapcore.Field) []slog.Attr {
+	res := make([]slog.Attr, 0, len(fields))
+	for i := range fields {
+		enc := zapcore.NewMapObjectEncoder()
+		fields[i].AddTo(enc)
+		for _, k := range slices.Sorted(maps.Keys(enc.Fields)) {
+			res = append(res, slog.Any(k, enc.Fields[k]))
+		}
+	}
+
+	return res
+}
+
+func slogLevel(level zapcore.Level) slog.Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return slog.LevelDebug
+	case zapcore.InfoLevel:
+		return slog.LevelInfo
+	case zapcore.WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
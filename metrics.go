@@ -0,0 +1,121 @@
+package endure
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/roadrunner-server/errors"
+)
+
+const (
+	metricsNamespace = "endure"
+	pluginLabel      = "plugin"
+	stateLabel       = "state"
+)
+
+// metrics is the observer, which exports the container lifecycle events as the prometheus metrics
+type metrics struct {
+	initDuration  *prometheus.HistogramVec
+	serveDuration *prometheus.HistogramVec
+	stopDuration  *prometheus.HistogramVec
+	// active and disabled vertices
+	vertices *prometheus.GaugeVec
+	// errors sent by the plugins to the Serve channel
+	serveErrors *prometheus.CounterVec
+	restarts    *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		initDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "init_duration_seconds",
+			Help:      "Duration of the plugin's Init call.",
+		}, []string{pluginLabel}),
+		serveDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "serve_duration_seconds",
+			Help:      "Duration of the plugin's Serve call.",
+		}, []string{pluginLabel}),
+		stopDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "stop_duration_seconds",
+			Help:      "Duration of the plugin's Stop call.",
+		}, []string{pluginLabel}),
+		vertices: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "vertices",
+			Help:      "Number of the registered plugins by the state (active or disabled).",
+		}, []string{stateLabel}),
+		serveErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "serve_errors_total",
+			Help:      "Number of the errors sent by the plugin to the Serve channel.",
+		}, []string{pluginLabel}),
+		restarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "restarts_total",
+			Help:      "Number of the plugin restarts made by the supervisor.",
+		}, []string{pluginLabel}),
+	}
+}
+
+func (m *metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.initDuration,
+		m.serveDuration,
+		m.stopDuration,
+		m.vertices,
+		m.serveErrors,
+		m.restarts,
+	}
+}
+
+func (m *metrics) OnEvent(event *Event) {
+	switch event.Type {
+	case EventRegistered:
+		m.vertices.WithLabelValues("active").Inc()
+	case EventDisabled:
+		m.vertices.WithLabelValues("active").Dec()
+		m.vertices.WithLabelValues("disabled").Inc()
+	case EventInitFinished:
+		m.initDuration.WithLabelValues(event.Plugin).Observe(event.Duration.Seconds())
+	case EventServeStarted:
+		m.serveDuration.WithLabelValues(event.Plugin).Observe(event.Duration.Seconds())
+	case EventStopFinished:
+		m.stopDuration.WithLabelValues(event.Plugin).Observe(event.Duration.Seconds())
+	case EventServeError:
+		m.serveErrors.WithLabelValues(event.Plugin).Inc()
+	case EventRestarted:
+		m.restarts.WithLabelValues(event.Plugin).Inc()
+	}
+}
+
+// exportMetrics subscribes the metrics to the lifecycle events, registers them with the registerer and returns the /metrics handler
+func (e *Endure) exportMetrics() (http.Handler, error) {
+	const op = errors.Op("endure_metrics")
+
+	m := newMetrics()
+	if e.metricsRegisterer != nil {
+		collectors := m.collectors()
+		for i := range collectors {
+			err := e.metricsRegisterer.Register(collectors[i])
+			if err != nil {
+				// e.g. prometheus.AlreadyRegisteredError, when another container uses the same registerer
+				for j := range i {
+					e.metricsRegisterer.Unregister(collectors[j])
+				}
+
+				return nil, errors.E(op, errors.Register, err)
+			}
+		}
+	}
+
+	e.observers = append(e.observers, m)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(m.collectors()...)
+
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{}), nil
+}
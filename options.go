@@ -1,7 +1,14 @@
 package endure
 
 import (
+	"log/slog"
+	"runtime"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/roadrunner-server/endure/v2/logger"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 // GracefulShutdownTimeout sets the timeout to kill the vertices is one or more of them are frozen
@@ -11,14 +18,137 @@ func GracefulShutdownTimeout(to time.Duration) Options {
 	}
 }
 
+// InitTimeout sets the container-wide deadline for every plugin's Init, zero (default) means no deadline
+// Plugins can override it by implementing the TimedInit interface
+func InitTimeout(to time.Duration) Options {
+	return func(endure *Endure) {
+		endure.initTimeout = to
+	}
+}
+
+// ParallelInit enables concurrent Init calls of the plugins within the same topological level
+// workers limits the number of concurrent Init calls, GOMAXPROCS is used when workers <= 0
+func ParallelInit(workers int) Options {
+	return func(endure *Endure) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+
+		endure.parallelInit = true
+		endure.initWorkers = workers
+	}
+}
+
+// DefaultRestartPolicy sets the restart policy for all plugins, which don't have their own policy
+func DefaultRestartPolicy(policy *RestartPolicy) Options {
+	return func(endure *Endure) {
+		endure.supervisor.defaultPolicy = policy
+	}
+}
+
+// PluginRestartPolicy sets the restart policy for the particular plugin, the plugin might override it by implementing the Restartable interface
+// plugin is the registered plugin value or its ID (e.g. "*redis.Plugin#cache"), instance or Named name, the ID targets the RegisterFunc and Supply plugins
+func PluginRestartPolicy(plugin any, policy *RestartPolicy) Options {
+	return func(endure *Endure) {
+		endure.supervisor.policies[plugin] = policy
+	}
+}
+
+// HealthProbes starts the HTTP server on the addr with the /health (Liveness) and /ready (Readiness) endpoints
+// The server is shut down on Stop, the bind error is returned from the Serve. See the AdminConfig.Probes to serve them on the admin server
+func HealthProbes(addr string) Options {
+	return func(endure *Endure) {
+		endure.probesAddr = addr
+	}
+}
+
+// Metrics registers the container lifecycle metrics (Init, Serve and Stop durations, active and disabled plugins, Serve errors and restarts) with the registerer
+// The registration error (e.g. the registerer is already used by another container) is returned from the Serve
+func Metrics(reg prometheus.Registerer) Options {
+	return func(endure *Endure) {
+		endure.metricsRegisterer = reg
+	}
+}
+
+// MetricsServer starts the HTTP server on the addr with the container lifecycle metrics on the /metrics endpoint
+// The server is shut down on Stop, the bind error is returned from the Serve. See the AdminConfig.Metrics to serve them on the admin server
+func MetricsServer(addr string) Options {
+	return func(endure *Endure) {
+		endure.metricsAddr = addr
+	}
+}
+
+// Tracing creates the OpenTelemetry spans of the lifecycle phases and the plugins' Init, Collects, Serve and Stop calls with the tracer provider
+// The trace context is passed to the context-aware Init and to the Stop, no spans are created by default
+func Tracing(tp trace.TracerProvider) Options {
+	return func(endure *Endure) {
+		endure.tracerProvider = tp
+	}
+}
+
+// ZapLogger sets the logger for the endure's internal logs instead of the default one
+func ZapLogger(log *zap.Logger) Options {
+	return func(endure *Endure) {
+		endure.log = log
+	}
+}
+
+// SlogLogger routes the endure's internal logs to the handler of the provided slog logger
+func SlogLogger(log *slog.Logger) Options {
+	return func(endure *Endure) {
+		endure.log = logger.FromSlogHandler(log.Handler())
+	}
+}
+
+// SlogHandler routes the endure's internal logs to the provided slog handler
+func SlogHandler(handler slog.Handler) Options {
+	return func(endure *Endure) {
+		endure.log = logger.FromSlogHandler(handler)
+	}
+}
+
+// Observe adds the observer of the container lifecycle events
+func Observe(observer Observer) Options {
+	return func(endure *Endure) {
+		endure.observers = append(endure.observers, observer)
+	}
+}
+
+// OnAmbiguity sets the policy for the unqualified Init arguments implemented by several plugins, AmbiguityWeight by default
+func OnAmbiguity(policy AmbiguityPolicy) Options {
+	return func(endure *Endure) {
+		endure.ambiguityPolicy = policy
+	}
+}
+
 func Visualize() Options {
 	return func(endure *Endure) {
 		endure.visualize = true
 	}
 }
 
+// EnableProfiler starts the admin server with the profiler (pprof) on the 0.0.0.0:6061
 func EnableProfiler() Options {
 	return func(endure *Endure) {
-		endure.profiler = true
+		endure.admin = &AdminConfig{}
+	}
+}
+
+// AdminServer starts the admin server with the profiler (pprof) and the endure's debug endpoints, the server is shut down on the Stop
+// nil config starts the server on the 0.0.0.0:6061
+func AdminServer(cfg *AdminConfig) Options {
+	return func(endure *Endure) {
+		if cfg == nil {
+			cfg = &AdminConfig{}
+		}
+
+		endure.admin = cfg
+	}
+}
+
+// OnCycle sets the policy for the plugins forming a dependency cycle, CycleWarn by default
+func OnCycle(policy CyclePolicy) Options {
+	return func(endure *Endure) {
+		endure.cyclePolicy = policy
 	}
 }
@@ -1,31 +1,83 @@
 package endure
 
 import (
+	"github.com/roadrunner-server/endure/v2/graph"
 	"go.uber.org/zap"
 )
 
 // poll is used to poll the errors from the vertex
 func (e *Endure) poll(r *result) {
+	// the vertex might be served again after the restart, stop the previous poller
+	stopCh := make(chan struct{})
+	e.pollersMu.Lock()
+	if prev, ok := e.pollers[r.vertex]; ok {
+		close(prev)
+	}
+	e.pollers[r.vertex] = stopCh
+	e.pollersMu.Unlock()
+
 	go func(res *result) {
-		for err := range res.errCh {
-			if err == nil {
-				continue
+		for {
+			select {
+			case <-stopCh:
+				return
+			case err, ok := <-res.errCh:
+				// the poller was stopped, while the plugin closed the channel (or sent an error) on Stop
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+
+				if !ok {
+					// channel was closed, the plugin might be restarted with the RestartAlways policy
+					e.supervise(res.vertex, nil)
+					return
+				}
+
+				if err == nil {
+					continue
+				}
+				// log error message
+				e.log.Error("plugin returned an error from the 'Serve' method", zap.Error(err), zap.String("plugin", res.vertexID))
+				e.emit(&Event{Type: EventServeError, Plugin: res.vertexID, Error: err})
+
+				// plugin was restarted by the supervisor, new poller is started
+				if e.supervise(res.vertex, err) {
+					return
+				}
+
+				// plugin failed, notify the dynamic collectors
+				e.mu.Lock()
+				e.uncollect(res.vertex)
+				e.mu.Unlock()
+
+				// set the error
+				res.err = err
+				// send handleErrorCh signal
+				e.handleErrorCh <- res
 			}
-			// log error message
-			e.log.Error("plugin returned an error from the 'Serve' method", zap.Error(err), zap.String("plugin", res.vertexID))
-			// set the error
-			res.err = err
-			// send handleErrorCh signal
-			e.handleErrorCh <- res
 		}
 	}(r)
 }
 
+// stopPoller stops polling the errors of the vertex
+func (e *Endure) stopPoller(vertex *graph.Vertex) {
+	e.pollersMu.Lock()
+	defer e.pollersMu.Unlock()
+
+	if ch, ok := e.pollers[vertex]; ok {
+		close(ch)
+		delete(e.pollers, vertex)
+	}
+}
+
 func (e *Endure) startMainThread() {
 	// main thread used to handle errors from vertices
 	go func() {
 		for res := range e.handleErrorCh {
 			e.log.Debug("processing error in the main thread", zap.String("id", res.vertexID))
+			e.lastErrors.set(res.vertex, res.err)
 			e.userResultsCh <- &Result{
 				Error:    res.err,
 				VertexID: res.vertexID,
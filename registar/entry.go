@@ -6,9 +6,11 @@ import (
 
 type returnedType struct {
 	retType reflect.Type
-	value   func() reflect.Value
+	value   ValueFunc
 	// methods, which used for the providers
 	method string
+	// name (qualifier) of the provided value
+	name string
 }
 
 type registarEntry struct {
@@ -16,6 +18,8 @@ type registarEntry struct {
 	returnedTypes []*returnedType
 	// plugin value
 	plugin any
+	// plugin name (qualifier)
+	name string
 	// weight
 	weight uint
 }
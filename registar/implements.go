@@ -5,7 +5,9 @@ type implements struct {
 	plugin any
 	// method will be non-empty if we have Provided dep
 	methods string
-	weight  uint
+	// name (qualifier) of the implementation
+	name   string
+	weight uint
 }
 
 func (i *implements) Plugin() any {
@@ -15,3 +17,7 @@ func (i *implements) Plugin() any {
 func (i *implements) Method() string {
 	return i.methods
 }
+
+func (i *implements) Name() string {
+	return i.name
+}
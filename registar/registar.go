@@ -1,103 +1,142 @@
 package registar
 
 import (
+	"context"
 	"reflect"
 	"sort"
 )
 
+// ValueFunc returns the value provided by the plugin, the error is returned by the provider method
+type ValueFunc func(ctx context.Context) (reflect.Value, error)
+
 type Registar struct {
-	// id - plugin
+	// id - plugin value, so instances of the same type are independent
 	// values - types, which plugin have
-	types map[reflect.Type]*registarEntry
+	types map[any]*registarEntry
 }
 
 func New() *Registar {
 	return &Registar{
-		types: make(map[reflect.Type]*registarEntry),
+		types: make(map[any]*registarEntry),
 	}
 }
 
-func (r *Registar) Insert(plugin any, retType reflect.Type, method string, weight uint) {
-	key := reflect.TypeOf(plugin)
-	if _, ok := r.types[key]; !ok {
-		r.types[key] = &registarEntry{}
+// Insert registers the type provided by the plugin, name is the qualifier of the provided value
+func (r *Registar) Insert(plugin any, retType reflect.Type, method string, name string, weight uint) {
+	if _, ok := r.types[plugin]; !ok {
+		r.types[plugin] = &registarEntry{}
 	}
 
-	r.types[key].returnedTypes = append(r.types[key].returnedTypes, &returnedType{
+	r.types[plugin].returnedTypes = append(r.types[plugin].returnedTypes, &returnedType{
 		retType: retType,
 		method:  method,
+		name:    name,
 	})
 
-	r.types[key].weight = weight
-	r.types[key].plugin = plugin
+	// plugin itself
+	if retType == reflect.TypeOf(plugin) {
+		r.types[plugin].name = name
+	}
+
+	r.types[plugin].weight = weight
+	r.types[plugin].plugin = plugin
 }
 
-func (r *Registar) Update(plugin any, tp reflect.Type, value func() reflect.Value) {
-	key := reflect.TypeOf(plugin)
-	if _, ok := r.types[key]; !ok {
+// Update sets the value of the type provided by the plugin via the method (empty for the plugin itself)
+func (r *Registar) Update(plugin any, tp reflect.Type, method string, value ValueFunc) {
+	if _, ok := r.types[plugin]; !ok {
 		return
 	}
 
 	// returned types
-	types := r.types[key].returnedTypes
+	types := r.types[plugin].returnedTypes
 
 	for i := range types {
-		if types[i].retType == tp {
+		if types[i].retType == tp && types[i].method == method {
 			types[i].value = value
 		}
 	}
 }
 
-func (r *Registar) Value(plugin any, tp reflect.Type) (reflect.Value, bool) {
-	key := reflect.TypeOf(plugin)
-	if _, ok := r.types[key]; !ok {
-		return reflect.Value{}, false
+func (r *Registar) Value(ctx context.Context, plugin any, tp reflect.Type) (reflect.Value, bool, error) {
+	if _, ok := r.types[plugin]; !ok {
+		return reflect.Value{}, false, nil
 	}
 	// returned types
-	types := r.types[key].returnedTypes
+	types := r.types[plugin].returnedTypes
 
 	for i := range types {
 		if types[i].retType == tp {
-			return types[i].value(), true
+			val, err := types[i].value(ctx)
+			return val, true, err
 		}
 	}
 
 	// initialized values for the particular type
-	return reflect.Value{}, false
+	return reflect.Value{}, false, nil
 }
 
 // TypeValue check that there are plugins (with Provides) that implement all types
-func (r *Registar) TypeValue(plugin any, tp reflect.Type) (reflect.Value, bool) {
-	key := reflect.TypeOf(plugin)
-	if _, ok := r.types[key]; !ok {
-		return reflect.Value{}, false
+// method selects the value provided by the particular method (empty for the plugin itself), so the plugin might provide several values of the same type
+// name selects the particular qualified value, any value is matched if empty
+// the error is returned by the provider method
+func (r *Registar) TypeValue(ctx context.Context, plugin any, tp reflect.Type, method string, name string) (reflect.Value, bool, error) {
+	if _, ok := r.types[plugin]; !ok {
+		return reflect.Value{}, false, nil
 	}
 
-	retTp := r.types[key]
+	retTp := r.types[plugin]
 
 	for i := range retTp.returnedTypes {
 		if retTp.returnedTypes[i].retType.Implements(tp) {
+			if retTp.returnedTypes[i].method != method {
+				continue
+			}
+
+			if name != "" && retTp.returnedTypes[i].name != name {
+				continue
+			}
+
 			if retTp.returnedTypes[i].value == nil {
-				return reflect.Value{}, false
+				return reflect.Value{}, false, nil
 			}
-			return retTp.returnedTypes[i].value(), true
+
+			val, err := retTp.returnedTypes[i].value(ctx)
+			return val, true, err
 		}
 	}
 
-	return reflect.Value{}, false
+	return reflect.Value{}, false, nil
+}
+
+// Provides returns the types provided by the plugin, except the plugin type itself
+func (r *Registar) Provides(plugin any) []reflect.Type {
+	if _, ok := r.types[plugin]; !ok {
+		return nil
+	}
+
+	var types []reflect.Type
+	for _, rt := range r.types[plugin].returnedTypes {
+		if rt.retType == reflect.TypeOf(plugin) {
+			continue
+		}
+
+		types = append(types, rt.retType)
+	}
+
+	return types
 }
 
 func (r *Registar) Remove(plugin any) {
-	delete(r.types, reflect.TypeOf(plugin))
+	delete(r.types, plugin)
 }
 
 // ImplementsExcept check for the deps which implements the type 'tp' except the plugin itself (or any other plugin)
 func (r *Registar) ImplementsExcept(tp reflect.Type, plugin any) []*implements {
 	var impl []*implements
-	excl := reflect.TypeOf(plugin)
 	// range over all registered types (basically all that we know about plugins and providers)
 	for k, entry := range r.types {
-		if k == excl {
+		if k == plugin {
 			continue
 		}
 		// iterate over types, provided by the user
@@ -105,10 +144,11 @@ func (r *Registar) ImplementsExcept(tp reflect.Type, plugin any) []*implements {
 
 		// our plugin might implement one of the needed types
 		// if not, check if the plugin provides some types which might implement the type
-		if k.Implements(tp) {
+		if reflect.TypeOf(k).Implements(tp) {
 			impl = append(impl, &implements{
 				plugin: entry.Plugin(),
 				weight: entry.Weight(),
+				name:   entry.name,
 			})
 			continue
 		}
@@ -122,16 +162,43 @@ func (r *Registar) ImplementsExcept(tp reflect.Type, plugin any) []*implements {
 						plugin:  entry.Plugin(),
 						weight:  entry.Weight(),
 						methods: provided.method,
+						name:    provided.name,
 					},
 				)
 			}
 		}
 	}
 
-	// sort by weight
-	sort.Slice(impl, func(i, j int) bool {
+	// sort by weight, plugins with the same weight are sorted by type and name to keep the order deterministic
+	sort.SliceStable(impl, func(i, j int) bool {
+		if impl[i].weight == impl[j].weight {
+			ti, tj := reflect.TypeOf(impl[i].plugin).String(), reflect.TypeOf(impl[j].plugin).String()
+			if ti == tj {
+				return impl[i].name < impl[j].name
+			}
+
+			return ti < tj
+		}
+
 		return impl[i].weight > impl[j].weight
 	})
 
 	return impl
 }
+
+// ImplementsNamed is the ImplementsExcept filtered by the name (qualifier), not filtered if the name is empty
+func (r *Registar) ImplementsNamed(tp reflect.Type, plugin any, name string) []*implements {
+	impl := r.ImplementsExcept(tp, plugin)
+	if name == "" {
+		return impl
+	}
+
+	res := make([]*implements, 0, len(impl))
+	for i := range impl {
+		if impl[i].name == name {
+			res = append(res, impl[i])
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,151 @@
+package endure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+)
+
+type phase uint8
+
+const (
+	phaseResolve phase = iota
+	phaseInit
+	phaseCollects
+	phaseServe
+	phaseStop
+)
+
+// PluginTimings is the time spent in the lifecycle phases of the plugin, durations are in nanoseconds in the JSON
+type PluginTimings struct {
+	Plugin string `json:"plugin"`
+	// ResolveEdges is the time spent on resolving the plugin's dependencies
+	ResolveEdges time.Duration `json:"resolve_edges"`
+	Init         time.Duration `json:"init"`
+	// Collects is the total time of the plugin's Collects callbacks
+	Collects time.Duration `json:"collects"`
+	// Serve is the duration of the (latest) Serve call
+	Serve time.Duration `json:"serve"`
+	Stop  time.Duration `json:"stop"`
+}
+
+// Report is the per-plugin timings report
+type Report struct {
+	// Plugins in the topological order
+	Plugins []*PluginTimings `json:"plugins"`
+	// CriticalPath is the most expensive dependency chain by the ResolveEdges + Init + Collects + Serve time of its plugins
+	CriticalPath []string `json:"critical_path"`
+	// CriticalPathDuration is the total time of the critical path
+	CriticalPathDuration time.Duration `json:"critical_path_duration"`
+}
+
+// JSON returns the report in the JSON format
+func (r *Report) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// WriteTable writes the report as a table
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PLUGIN\tRESOLVE\tINIT\tCOLLECTS\tSERVE\tSTOP")
+	for _, p := range r.Plugins {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", p.Plugin, p.ResolveEdges, p.Init, p.Collects, p.Serve, p.Stop)
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "critical path (%s): %s\n", r.CriticalPathDuration, strings.Join(r.CriticalPath, " -> "))
+	return err
+}
+
+// String returns the report as a table
+func (r *Report) String() string {
+	var sb strings.Builder
+	_ = r.WriteTable(&sb)
+	return sb.String()
+}
+
+// timings records the durations of the lifecycle phases, Init and Stop might run concurrently
+type timings struct {
+	mu      sync.Mutex
+	plugins map[*graph.Vertex]*PluginTimings
+}
+
+func newTimings() *timings {
+	return &timings{
+		plugins: make(map[*graph.Vertex]*PluginTimings),
+	}
+}
+
+func (t *timings) record(vertex *graph.Vertex, ph phase, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pt, ok := t.plugins[vertex]
+	if !ok {
+		pt = &PluginTimings{Plugin: vertex.String()}
+		t.plugins[vertex] = pt
+	}
+
+	switch ph {
+	case phaseResolve:
+		pt.ResolveEdges = d
+	case phaseInit:
+		pt.Init = d
+	case phaseCollects:
+		pt.Collects += d
+	case phaseServe:
+		pt.Serve = d
+	case phaseStop:
+		pt.Stop = d
+	}
+}
+
+func (t *timings) get(vertex *graph.Vertex) PluginTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if pt, ok := t.plugins[vertex]; ok {
+		return *pt
+	}
+
+	return PluginTimings{Plugin: vertex.String()}
+}
+
+// Report returns the per-plugin timings of the lifecycle phases and the critical path of the startup
+// The report is complete after the Serve (Stop timings are added after the Stop)
+func (e *Endure) Report() *Report {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	vertices := e.graph.TopologicalOrder()
+	report := &Report{
+		Plugins: make([]*PluginTimings, 0, len(vertices)),
+	}
+
+	for i := range vertices {
+		pt := e.timings.get(vertices[i])
+		report.Plugins = append(report.Plugins, &pt)
+	}
+
+	path, total := e.graph.CriticalPath(func(v *graph.Vertex) int64 {
+		pt := e.timings.get(v)
+		return int64(pt.ResolveEdges + pt.Init + pt.Collects + pt.Serve)
+	})
+
+	for i := range path {
+		report.CriticalPath = append(report.CriticalPath, path[i].String())
+	}
+	report.CriticalPathDuration = time.Duration(total)
+
+	return report
+}
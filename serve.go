@@ -1,11 +1,14 @@
 package endure
 
 import (
+	"context"
 	"reflect"
 	"sort"
+	"time"
 
 	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/errors"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
@@ -25,38 +28,68 @@ func (e *Endure) serve() error {
 		return errors.E(errors.Str("error occurred, nothing to run"))
 	}
 
+	ctx, span := e.startPhase(context.Background(), "serve")
+
 	for i := range serveVertices {
-		if !serveVertices[i].IsActive() {
-			continue
+		err := e.serveVertex(ctx, serveVertices[i])
+		if err != nil {
+			endSpan(span, err)
+			return err
 		}
+	}
 
-		if !reflect.TypeOf(serveVertices[i].Plugin()).Implements(reflect.TypeFor[Service]()) {
-			continue
-		}
+	span.End()
+
+	return nil
+}
+
+// serveVertex calls the Serve method of the active vertex (if implemented) and starts polling its errors channel
+func (e *Endure) serveVertex(ctx context.Context, vertex *graph.Vertex) error {
+	if !vertex.IsActive() {
+		return nil
+	}
+
+	plugin, ok := servicePlugin(vertex)
+	if !ok {
+		return nil
+	}
+
+	delete(e.halted, vertex)
+
+	serveMethod, _ := reflect.TypeOf(plugin).MethodByName(ServeMethodName)
+
+	e.log.Debug("calling serve method", zap.String("plugin", vertex.String()))
+	_, span := e.startCall(ctx, vertex, ServeMethodName)
+	defer span.End()
 
-		serveMethod, _ := reflect.TypeOf(serveVertices[i].Plugin()).MethodByName(ServeMethodName)
-
-		e.log.Debug("calling serve method", zap.String("plugin", serveVertices[i].ID().String()))
-		ret := serveMethod.Func.Call([]reflect.Value{reflect.ValueOf(serveVertices[i].Plugin())})[0].Interface()
-		if ret != nil {
-			if errCh, ok := ret.(chan error); ok && errCh != nil {
-				// check if we have an error in the user's channel
-				select {
-				case er := <-errCh:
-					return errors.E(
-						errors.FunctionCall,
-						errors.Errorf(
-							"serve error from the plugin %s stopping execution, error: %v",
-							serveVertices[i].ID().String(), er),
-					)
-				default:
-					// if we don't have an error in the user's channel, activate poller
-					e.poll(&result{
-						// listen for the user's error channel
-						errCh:    errCh,
-						vertexID: serveVertices[i].ID().String(),
-					})
-				}
+	start := time.Now()
+	ret := serveMethod.Func.Call([]reflect.Value{reflect.ValueOf(plugin)})[0].Interface()
+	e.timings.record(vertex, phaseServe, time.Since(start))
+	e.emit(&Event{Type: EventServeStarted, Plugin: vertex.String(), Duration: time.Since(start)})
+	if ret != nil {
+		if errCh, ok := ret.(chan error); ok && errCh != nil {
+			// check if we have an error in the user's channel
+			select {
+			case er := <-errCh:
+				e.emit(&Event{Type: EventServeError, Plugin: vertex.String(), Error: er})
+				// er is nil when the plugin sent nil or closed the channel
+				err := errors.E(
+					errors.FunctionCall,
+					errors.Errorf(
+						"serve error from the plugin %s stopping execution, error: %v",
+						vertex.String(), er),
+				)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return err
+			default:
+				// if we don't have an error in the user's channel, activate poller
+				e.poll(&result{
+					// listen for the user's error channel
+					errCh:    errCh,
+					vertex:   vertex,
+					vertexID: vertex.String(),
+				})
 			}
 		}
 	}
@@ -6,70 +6,161 @@ import (
 	"reflect"
 	"slices"
 	"sync"
+	"time"
 
+	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/errors"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
-func (e *Endure) stop() error {
+// StopResult is the outcome of the plugin's Stop method
+type StopResult struct {
+	// VertexID is the ID of the stopped plugin
+	VertexID string
+	// Duration of the Stop call, equals to the stop timeout when the plugin did not stop in time
+	Duration time.Duration
+	// Error returned from the Stop or the timeout error
+	Error error
+	// TimedOut is true when the plugin did not stop within the GracefulShutdownTimeout
+	TimedOut bool
+}
+
+func (e *Endure) stop() ([]*StopResult, error) {
 	/*
 		topological order
 	*/
 	vertices := e.graph.TopologicalOrder()
 
 	if len(vertices) == 0 {
-		return errors.E(errors.Str("error occurred, nothing to run"))
+		return nil, errors.E(errors.Str("error occurred, nothing to run"))
 	}
 
-	mu := new(sync.Mutex)
+	// no restarts after this point
+	e.stopped.Store(true)
+	e.serving.Store(false)
+
+	report := make([]*StopResult, 0, len(vertices))
 	errs := make([]error, 0, 2)
-	wg := &sync.WaitGroup{}
-	wg.Add(len(vertices))
 
-	// reverse order
-	for i, vertice := range slices.Backward(vertices) {
-		if !vertice.IsActive() {
-			wg.Done()
+	ctx, span := e.startPhase(context.Background(), "stop")
+	defer span.End()
+
+	// reverse topological order, level by level: dependents should be stopped before their dependencies
+	for _, level := range slices.Backward(e.graph.TopologicalLevels()) {
+		results := e.stopLevel(ctx, level)
+		for i := range results {
+			if results[i].Error != nil {
+				errs = append(errs, results[i].Error)
+			}
+		}
+
+		report = append(report, results...)
+	}
+
+	if len(errs) > 0 {
+		err := stderr.Join(errs...)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return report, err
+	}
+
+	return report, nil
+}
+
+// stopLevel stops all plugins of the level concurrently and waits for them not longer than the stop timeout
+func (e *Endure) stopLevel(ctx context.Context, level []*graph.Vertex) []*StopResult {
+	const op = errors.Op("endure_stop")
+
+	ctx, cancel := context.WithTimeout(ctx, e.stopTimeout)
+	defer cancel()
+
+	started := time.Now()
+	vertices := make([]*graph.Vertex, 0, len(level))
+	done := make([]chan *StopResult, 0, len(level))
+	// StopFinished is emitted once: either by the plugin or on the timeout
+	finished := make([]*sync.Once, 0, len(level))
+
+	for _, vertex := range level {
+		if !vertex.IsActive() {
+			continue
+		}
+
+		// already stopped via the admin control endpoint
+		if _, ok := e.halted[vertex]; ok {
 			continue
 		}
 
-		if !reflect.TypeOf(vertice.Plugin()).Implements(reflect.TypeFor[Service]()) {
-			wg.Done()
+		plugin, ok := servicePlugin(vertex)
+		if !ok {
 			continue
 		}
 
-		go func(i int) {
-			defer wg.Done()
-			stopMethod, _ := reflect.TypeOf(vertices[i].Plugin()).MethodByName(StopMethodName)
+		ch := make(chan *StopResult, 1)
+		once := &sync.Once{}
+		vertices = append(vertices, vertex)
+		done = append(done, ch)
+		finished = append(finished, once)
 
-			inVals := make([]reflect.Value, 0, 2)
-			inVals = append(inVals, reflect.ValueOf(vertices[i].Plugin()))
+		go func() {
+			stopMethod, _ := reflect.TypeOf(plugin).MethodByName(StopMethodName)
 
 			e.log.Debug(
 				"calling stop function",
-				zap.String("plugin", vertices[i].ID().String()),
+				zap.String("plugin", vertex.String()),
 			)
 
-			ctx, cancel := context.WithTimeout(context.Background(), e.stopTimeout)
-			inVals = append(inVals, reflect.ValueOf(ctx))
+			e.emit(&Event{Type: EventStopStarted, Plugin: vertex.String()})
 
-			ret := stopMethod.Func.Call(inVals)[0].Interface()
+			sctx, span := e.startCall(ctx, vertex, StopMethodName)
+
+			start := time.Now()
+			res := &StopResult{
+				VertexID: vertex.String(),
+			}
+
+			ret := stopMethod.Func.Call([]reflect.Value{reflect.ValueOf(plugin), reflect.ValueOf(sctx)})[0].Interface()
+			res.Duration = time.Since(start)
+			e.timings.record(vertex, phaseStop, res.Duration)
 			if ret != nil {
-				e.log.Error("failed to stop the plugin", zap.String("name", vertices[i].ID().String()), zap.Error(ret.(error)))
-				mu.Lock()
-				errs = append(errs, ret.(error))
-				mu.Unlock()
+				e.log.Error("failed to stop the plugin", zap.String("name", vertex.String()), zap.Error(ret.(error)))
+				res.Error = ret.(error)
 			}
+			endSpan(span, res.Error)
 
-			cancel()
-		}(i)
+			once.Do(func() {
+				e.emit(&Event{Type: EventStopFinished, Plugin: res.VertexID, Duration: res.Duration, Error: res.Error})
+			})
+			ch <- res
+		}()
 	}
 
-	wg.Wait()
+	results := make([]*StopResult, 0, len(vertices))
+	for i := range vertices {
+		var res *StopResult
+		select {
+		case res = <-done[i]:
+		case <-ctx.Done():
+			// the level deadline is exceeded, collect the plugins which managed to stop
+			select {
+			case res = <-done[i]:
+			default:
+				e.log.Error("plugin stop timeout exceeded", zap.String("name", vertices[i].String()), zap.Duration("timeout", e.stopTimeout))
+				res = &StopResult{
+					VertexID: vertices[i].String(),
+					Duration: time.Since(started),
+					Error:    errors.E(op, errors.TimeOut, errors.Errorf("plugin %s did not stop within %s", vertices[i].String(), e.stopTimeout)),
+					TimedOut: true,
+				}
+				e.timings.record(vertices[i], phaseStop, res.Duration)
+				finished[i].Do(func() {
+					e.emit(&Event{Type: EventStopFinished, Plugin: res.VertexID, Duration: res.Duration, Error: res.Error})
+				})
+			}
+		}
 
-	if len(errs) > 0 {
-		return stderr.Join(errs...)
+		results = append(results, res)
 	}
 
-	return nil
+	return results
 }
@@ -0,0 +1,216 @@
+package endure
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/errors"
+	"go.uber.org/zap"
+)
+
+// RestartMode declares when the plugin should be restarted
+type RestartMode uint8
+
+const (
+	// RestartNever forwards Serve errors to the user (default)
+	RestartNever RestartMode = iota
+	// RestartOnFailure restarts the plugin when it sends an error to the Serve channel
+	RestartOnFailure
+	// RestartAlways restarts the plugin on error and when the Serve channel was closed
+	RestartAlways
+)
+
+// defaultInitialBackoff is used when the policy has no InitialBackoff, so the failing plugin is not restarted in a loop
+const defaultInitialBackoff = time.Millisecond * 100
+
+// RestartPolicy declares how the supervisor restarts the plugin
+type RestartPolicy struct {
+	Mode RestartMode
+	// InitialBackoff is the delay before the first restart, doubled on every next restart in the window, 100ms if zero
+	InitialBackoff time.Duration
+	// MaxBackoff limits the backoff, no limit if zero
+	MaxBackoff time.Duration
+	// MaxRestarts is the number of restarts allowed within the Window, unlimited if zero
+	// when exceeded, the error is forwarded to the user as a *Result
+	MaxRestarts int
+	// Window is the period for the MaxRestarts, the whole container lifetime if zero
+	Window time.Duration
+	// RestartDependents restarts all plugins which depend on the failed plugin as well
+	RestartDependents bool
+}
+
+// Restartable is optional to implement, the returned policy overrides the policies from the Options
+type Restartable interface {
+	RestartPolicy() *RestartPolicy
+}
+
+// backoff returns the delay before the n-th (starting from 1) restart
+func (rp *RestartPolicy) backoff(n int) time.Duration {
+	d := rp.InitialBackoff
+	if d <= 0 {
+		d = defaultInitialBackoff
+	}
+
+	for i := 1; i < n; i++ {
+		d *= 2
+		if rp.MaxBackoff > 0 && d >= rp.MaxBackoff {
+			return rp.MaxBackoff
+		}
+	}
+
+	if rp.MaxBackoff > 0 && d > rp.MaxBackoff {
+		return rp.MaxBackoff
+	}
+
+	return d
+}
+
+// supervisor holds the restart policies and the restarts history
+type supervisor struct {
+	mu sync.Mutex
+	// container-wide policy
+	defaultPolicy *RestartPolicy
+	// policies from the Options, keyed by the plugin or its ID (name)
+	policies map[any]*RestartPolicy
+	// restarts within the window
+	restarts map[*graph.Vertex][]time.Time
+}
+
+func newSupervisor() *supervisor {
+	return &supervisor{
+		policies: make(map[any]*RestartPolicy),
+		restarts: make(map[*graph.Vertex][]time.Time),
+	}
+}
+
+func (s *supervisor) policy(vertex *graph.Vertex) *RestartPolicy {
+	if val, ok := pluginAs[Restartable](vertex); ok {
+		return val.RestartPolicy()
+	}
+
+	if p, ok := s.policies[vertex.Plugin()]; ok {
+		return p
+	}
+
+	for _, id := range vertexIDs(vertex) {
+		if p, ok := s.policies[id]; ok {
+			return p
+		}
+	}
+
+	return s.defaultPolicy
+}
+
+// allow records the restart and returns its number within the window, false when the restarts limit is exceeded
+func (s *supervisor) allow(vertex *graph.Vertex, policy *RestartPolicy) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	history := s.restarts[vertex]
+	if policy.Window > 0 {
+		history = slices.DeleteFunc(history, func(t time.Time) bool {
+			return now.Sub(t) > policy.Window
+		})
+	}
+
+	if policy.MaxRestarts > 0 && len(history) >= policy.MaxRestarts {
+		s.restarts[vertex] = history
+		return len(history), false
+	}
+
+	history = append(history, now)
+	s.restarts[vertex] = history
+
+	return len(history), true
+}
+
+// supervise restarts the vertex according to its restart policy
+// err is nil when the Serve channel was closed
+// returns true if the plugin was restarted, and the error should not be forwarded to the user
+func (e *Endure) supervise(vertex *graph.Vertex, err error) bool {
+	policy := e.supervisor.policy(vertex)
+	if policy == nil || policy.Mode == RestartNever {
+		return false
+	}
+
+	if err == nil && policy.Mode != RestartAlways {
+		return false
+	}
+
+	if e.stopped.Load() {
+		return false
+	}
+
+	n, ok := e.supervisor.allow(vertex, policy)
+	if !ok {
+		e.log.Error("plugin restarts limit exceeded", zap.String("plugin", vertex.String()), zap.Int("restarts", n))
+		return false
+	}
+
+	backoff := policy.backoff(n)
+	e.log.Warn("restarting plugin", zap.String("plugin", vertex.String()), zap.Int("restart", n), zap.Duration("backoff", backoff), zap.Error(err))
+	time.Sleep(backoff)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// container was stopped while we were waiting
+	if e.stopped.Load() {
+		return true
+	}
+
+	rerr := e.restart(vertex, policy.RestartDependents)
+	if rerr != nil {
+		e.log.Error("failed to restart the plugin", zap.String("plugin", vertex.String()), zap.Error(rerr))
+		return false
+	}
+
+	e.emit(&Event{Type: EventRestarted, Plugin: vertex.String(), Error: err})
+
+	return true
+}
+
+// restart stops the vertex (and its dependents) in the reverse topological order and serves them again in the topological order
+func (e *Endure) restart(vertex *graph.Vertex, withDependents bool) (err error) {
+	const op = errors.Op("endure_restart")
+
+	ctx, span := e.startPhase(context.Background(), "restart")
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	vertices := []*graph.Vertex{vertex}
+	if withDependents {
+		vertices = e.graph.Dependents(vertex.Plugin())
+	}
+
+	for _, v := range slices.Backward(vertices) {
+		// the plugin might close its Serve channel or send an error on Stop, the old poller should not supervise it
+		e.stopPoller(v)
+		e.uncollect(v)
+		res := e.stopLevel(ctx, []*graph.Vertex{v})
+		for i := range res {
+			if res[i].Error != nil {
+				e.log.Warn("plugin stop error during the restart", zap.String("plugin", res[i].VertexID), zap.Error(res[i].Error))
+			}
+		}
+	}
+
+	for _, v := range vertices {
+		err = e.serveVertex(ctx, v)
+		if err != nil {
+			return errors.E(op, err)
+		}
+
+		err = e.collectFrom(ctx, v)
+		if err != nil {
+			e.log.Warn("failed to pass the restarted plugin to the collectors", zap.String("plugin", v.String()), zap.Error(err))
+		}
+	}
+
+	return nil
+}
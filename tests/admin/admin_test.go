@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/admin/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/admin/plugin2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_AdminServerUnixSocket(t *testing.T) {
+	// unix socket path length is limited, t.TempDir might be too long
+	dir, err := os.MkdirTemp("", "endure")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	socket := filepath.Join(dir, "admin.sock")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/custom", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("custom"))
+	})
+
+	c := endure.New(slog.LevelDebug, endure.AdminServer(&endure.AdminConfig{
+		Network: "unix",
+		Addr:    socket,
+		Mux:     mux,
+	}))
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.NoError(t, err)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://admin/debug/endure/plugins")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var states []*endure.PluginState
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&states))
+	_ = resp.Body.Close()
+
+	require.Len(t, states, 2)
+	byName := make(map[string]*endure.PluginState, len(states))
+	for _, s := range states {
+		byName[s.Plugin] = s
+	}
+	assert.True(t, byName["*plugin1.Plugin1"].Active)
+	assert.False(t, byName["*plugin2.Plugin2"].Active)
+
+	resp, err = client.Get("http://admin/debug/endure/graph")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Contains(t, string(body), "digraph")
+
+	resp, err = client.Get("http://admin/debug/pprof/")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	// user's handlers are served by the same server
+	resp, err = client.Get("http://admin/custom")
+	require.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "custom", string(body))
+
+	require.NoError(t, c.Stop())
+
+	// server is shut down with the container
+	client.CloseIdleConnections()
+	_, err = client.Get("http://admin/debug/endure/plugins")
+	assert.Error(t, err)
+}
+
+func TestEndure_AdminServerBindError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	c := endure.New(slog.LevelDebug, endure.AdminServer(&endure.AdminConfig{Addr: ln.Addr().String()}))
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	assert.Error(t, err)
+}
+
+func TestEndure_AdminServerListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := endure.New(slog.LevelDebug, endure.AdminServer(&endure.AdminConfig{Listener: ln}))
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/debug/endure/report")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	report := &endure.Report{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(report))
+	_ = resp.Body.Close()
+	require.Len(t, report.Plugins, 1)
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_AdminServerProbesMetrics(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := endure.New(slog.LevelDebug, endure.AdminServer(&endure.AdminConfig{
+		Listener: ln,
+		Probes:   true,
+		Metrics:  true,
+	}))
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.NoError(t, err)
+
+	for _, path := range []string{"/health", "/ready"} {
+		resp, err := http.Get("http://" + ln.Addr().String() + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Contains(t, string(body), `endure_init_duration_seconds_count{plugin="*plugin1.Plugin1"} 1`)
+
+	require.NoError(t, c.Stop())
+
+	// the admin server is shut down on Stop
+	_, err = http.Get("http://" + ln.Addr().String() + "/health")
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package plugin1
+
+import (
+	"context"
+)
+
+type Plugin1 struct{}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	return nil
+}
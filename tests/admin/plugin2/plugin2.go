@@ -0,0 +1,12 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/errors"
+)
+
+type Plugin2 struct{}
+
+func (p *Plugin2) Init() error {
+	const op = errors.Op("plugin2_init")
+	return errors.E(op, errors.Disabled)
+}
@@ -0,0 +1,38 @@
+package collectors
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/collectors/router"
+	"github.com/roadrunner-server/endure/v2/tests/collectors/routes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_CollectsWithSource(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	r := &router.Router{}
+	require.NoError(t, c.RegisterAll(r, &routes.Users{}, &routes.Orders{}))
+	require.NoError(t, c.Init())
+
+	assert.Equal(t, map[string]string{
+		"/users":  "*routes.Users",
+		"/orders": "*routes.Orders",
+	}, r.Routes())
+	assert.Equal(t, uint(5), r.Weights()["*routes.Users"])
+	assert.Equal(t, uint(1), r.Weights()["*routes.Orders"])
+}
+
+func TestEndure_CollectsError(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.RegisterAll(&router.Router{}, &routes.Users{}, &routes.Accounts{}))
+
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "*router.Router")
+	assert.Contains(t, err.Error(), "route /users is already registered")
+}
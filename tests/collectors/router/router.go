@@ -0,0 +1,45 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Route interface {
+	Path() string
+}
+
+// Router rejects duplicated routes
+type Router struct {
+	routes  map[string]string
+	weights map[string]uint
+}
+
+func (r *Router) Init() error {
+	r.routes = make(map[string]string)
+	r.weights = make(map[string]uint)
+	return nil
+}
+
+func (r *Router) Collects() []*dep.In {
+	return []*dep.In{
+		dep.CollectE(func(route Route, src *dep.Source) error {
+			if prev, ok := r.routes[route.Path()]; ok {
+				return fmt.Errorf("route %s is already registered by %s", route.Path(), prev)
+			}
+
+			r.routes[route.Path()] = src.ID
+			r.weights[src.ID] = src.Weight
+			return nil
+		}),
+	}
+}
+
+func (r *Router) Routes() map[string]string {
+	return r.routes
+}
+
+func (r *Router) Weights() map[string]uint {
+	return r.weights
+}
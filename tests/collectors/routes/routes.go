@@ -0,0 +1,41 @@
+package routes
+
+// Users serves the /users route
+type Users struct {
+}
+
+func (u *Users) Init() error {
+	return nil
+}
+
+func (u *Users) Path() string {
+	return "/users"
+}
+
+func (u *Users) Weight() uint {
+	return 5
+}
+
+// Orders serves the /orders route
+type Orders struct {
+}
+
+func (o *Orders) Init() error {
+	return nil
+}
+
+func (o *Orders) Path() string {
+	return "/orders"
+}
+
+// Accounts serves the /users route as well
+type Accounts struct {
+}
+
+func (a *Accounts) Init() error {
+	return nil
+}
+
+func (a *Accounts) Path() string {
+	return "/users"
+}
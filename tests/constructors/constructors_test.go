@@ -0,0 +1,98 @@
+package constructors
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/constructors/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/constructors/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/constructors/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_RegisterFunc(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p2 := &plugin2.Plugin2{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, p2))
+	require.NoError(t, c.RegisterFunc(server.NewServer))
+	require.NoError(t, c.Init())
+
+	assert.Equal(t, []string{"*plugin1.Plugin1", "*server.Server", "*plugin2.Plugin2"}, c.Plugins())
+	require.NotNil(t, p2.Addr())
+	assert.Equal(t, "srv:8080", p2.Addr().Addr())
+
+	srv := p2.Addr().(*server.Server)
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+	assert.True(t, srv.Served())
+
+	require.NoError(t, c.Stop())
+	assert.True(t, srv.Stopped())
+}
+
+func TestEndure_RegisterFuncError(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.RegisterFunc(server.NewBroken))
+
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken server")
+}
+
+func TestEndure_RegisterFuncNamed(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.RegisterFuncNamed("public", server.NewServer))
+	require.NoError(t, c.RegisterFuncNamed("internal", server.NewServer))
+	require.NoError(t, c.Init())
+
+	assert.Contains(t, c.Plugins(), "public")
+	assert.Contains(t, c.Plugins(), "internal")
+
+	require.Error(t, c.RegisterFuncNamed("", server.NewServer))
+}
+
+func TestEndure_RegisterFuncOptionalInterfaces(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.RegisterFunc(server.NewProbe))
+	require.NoError(t, c.Init())
+
+	// Named constructed value
+	assert.Contains(t, c.Plugins(), "probe")
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	// Readiness of the constructed value
+	rep := c.Ready()
+	require.Len(t, rep.Plugins, 1)
+	assert.Equal(t, "*server.Probe", rep.Plugins[0].Plugin)
+
+	// Serve error is handled by the restart policy of the constructed value
+	select {
+	case r := <-res:
+		t.Fatalf("error should be handled by the supervisor, got: %v", r.Error)
+	case <-time.After(time.Millisecond * 200):
+	}
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_RegisterFuncInvalid(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.Error(t, c.RegisterFunc(&plugin1.Plugin1{}))
+	require.Error(t, c.RegisterFunc(func() error { return nil }))
+	// parameters are resolved as the Init dependencies, should be interfaces
+	require.Error(t, c.RegisterFunc(func(*plugin1.Plugin1) (*server.Server, error) { return nil, nil }))
+}
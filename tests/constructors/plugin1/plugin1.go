@@ -0,0 +1,13 @@
+package plugin1
+
+// Plugin1 is the logger plugin
+type Plugin1 struct {
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Prefix() string {
+	return "srv"
+}
@@ -0,0 +1,19 @@
+package plugin2
+
+type Addr interface {
+	Addr() string
+}
+
+// Plugin2 depends on the value created by the constructor
+type Plugin2 struct {
+	addr Addr
+}
+
+func (p *Plugin2) Init(addr Addr) error {
+	p.addr = addr
+	return nil
+}
+
+func (p *Plugin2) Addr() Addr {
+	return p.addr
+}
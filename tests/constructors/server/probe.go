@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+)
+
+// Probe is the third-party type implementing the optional interfaces, created via the constructor
+type Probe struct {
+	serves atomic.Int64
+}
+
+func NewProbe(Logger) *Probe {
+	return &Probe{}
+}
+
+// Serve fails on the first call only
+func (p *Probe) Serve() chan error {
+	errCh := make(chan error, 1)
+	if p.serves.Add(1) == 1 {
+		go func() {
+			time.Sleep(time.Millisecond * 50)
+			errCh <- errors.New("probe failed")
+		}()
+	}
+
+	return errCh
+}
+
+func (p *Probe) Stop(context.Context) error {
+	return nil
+}
+
+func (p *Probe) Ready() *endure.Status {
+	return &endure.Status{OK: true}
+}
+
+func (p *Probe) Name() string {
+	return "probe"
+}
+
+func (p *Probe) RestartPolicy() *endure.RestartPolicy {
+	return &endure.RestartPolicy{Mode: endure.RestartOnFailure, InitialBackoff: time.Millisecond}
+}
+
+func (p *Probe) Serves() int64 {
+	return p.serves.Load()
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"errors"
+)
+
+type Logger interface {
+	Prefix() string
+}
+
+// Server is the third-party type, created via the constructor
+type Server struct {
+	log     Logger
+	served  bool
+	stopped bool
+}
+
+func NewServer(log Logger) (*Server, error) {
+	return &Server{log: log}, nil
+}
+
+func NewBroken(Logger) (*Server, error) {
+	return nil, errors.New("broken server")
+}
+
+func (s *Server) Addr() string {
+	return s.log.Prefix() + ":8080"
+}
+
+func (s *Server) Serve() chan error {
+	s.served = true
+	return make(chan error, 1)
+}
+
+func (s *Server) Stop(context.Context) error {
+	s.stopped = true
+	return nil
+}
+
+func (s *Server) Served() bool {
+	return s.served
+}
+
+func (s *Server) Stopped() bool {
+	return s.stopped
+}
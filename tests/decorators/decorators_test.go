@@ -0,0 +1,56 @@
+package decorators
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/decorators/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/decorators/plugin2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type wrapped struct {
+	name string
+	next plugin1.Storage
+}
+
+func (w *wrapped) Get(key string) string {
+	return w.name + "(" + w.next.Get(key) + ")"
+}
+
+func tracing(s plugin1.Storage) plugin1.Storage {
+	return &wrapped{name: "tracing", next: s}
+}
+
+func metrics(s plugin1.Storage) plugin1.Storage {
+	return &wrapped{name: "metrics", next: s}
+}
+
+func TestEndure_Decorate(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p2 := &plugin2.Plugin2{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, p2))
+	require.NoError(t, c.Decorate(tracing))
+	require.NoError(t, c.Decorate(metrics))
+	require.NoError(t, c.Init())
+
+	// first registered decorator is the innermost
+	require.NotNil(t, p2.Storage())
+	assert.Equal(t, "metrics(tracing(key))", p2.Storage().Get("key"))
+
+	require.Len(t, p2.Collected(), 1)
+	assert.Equal(t, "metrics(tracing(key))", p2.Collected()[0].Get("key"))
+
+	assert.Contains(t, c.DotGraph(), "plugin1.Storage decorated by")
+}
+
+func TestEndure_DecorateInvalid(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.Error(t, c.Decorate(&plugin1.Plugin1{}))
+	require.Error(t, c.Decorate(func(s plugin1.Storage) string { return "" }))
+	require.Error(t, c.Decorate(func(s *plugin1.Plugin1) *plugin1.Plugin1 { return s }))
+}
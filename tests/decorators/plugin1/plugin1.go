@@ -0,0 +1,17 @@
+package plugin1
+
+type Storage interface {
+	Get(key string) string
+}
+
+// Plugin1 is the storage
+type Plugin1 struct {
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Get(key string) string {
+	return key
+}
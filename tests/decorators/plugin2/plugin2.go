@@ -0,0 +1,33 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/decorators/plugin1"
+)
+
+// Plugin2 uses the storage and collects all storages
+type Plugin2 struct {
+	storage   plugin1.Storage
+	collected []plugin1.Storage
+}
+
+func (p *Plugin2) Init(s plugin1.Storage) error {
+	p.storage = s
+	return nil
+}
+
+func (p *Plugin2) Collects() []*dep.In {
+	return []*dep.In{
+		dep.Collect(func(s plugin1.Storage) {
+			p.collected = append(p.collected, s)
+		}),
+	}
+}
+
+func (p *Plugin2) Storage() plugin1.Storage {
+	return p.storage
+}
+
+func (p *Plugin2) Collected() []plugin1.Storage {
+	return p.collected
+}
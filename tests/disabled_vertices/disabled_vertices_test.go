@@ -102,3 +102,19 @@ func TestOneSurvived(t *testing.T) {
 	_, err = cont.Serve()
 	require.NoError(t, err)
 }
+
+func TestOneSurvivedParallelInit(t *testing.T) {
+	cont := endure.New(slog.LevelDebug, endure.ParallelInit(2))
+
+	err := cont.RegisterAll(
+		&plugin6.Plugin6{},
+		&plugin7.Plugin7{},
+		&plugin8.Plugin8{},
+		&plugin9.Plugin9{},
+		&plugin5.Plugin5{},
+	)
+
+	require.NoError(t, err)
+	require.NoError(t, cont.Init())
+	assert.Equal(t, []string{"*plugin5.Plugin5"}, cont.Plugins())
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Handler interface {
+	Route() string
+}
+
+// Audit rejects every handler
+type Audit struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (a *Audit) Init() error {
+	return nil
+}
+
+func (a *Audit) Collects() []*dep.In {
+	return []*dep.In{
+		dep.FitsE(func(any, *dep.Source) error {
+			a.mu.Lock()
+			a.calls++
+			a.mu.Unlock()
+
+			return errors.New("handler rejected")
+		}, (*Handler)(nil)).WithUncollect(func(any, *dep.Source) {}),
+	}
+}
+
+func (a *Audit) Calls() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.calls
+}
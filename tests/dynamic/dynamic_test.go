@@ -0,0 +1,143 @@
+package dynamic
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/dynamic/audit"
+	"github.com/roadrunner-server/endure/v2/tests/dynamic/handler"
+	"github.com/roadrunner-server/endure/v2/tests/dynamic/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_DynamicJoin(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	r := &router.Router{}
+	users := &handler.Handler{Path: "/users"}
+	require.NoError(t, c.RegisterAll(r, users))
+	require.NoError(t, c.Init())
+	assert.Equal(t, map[string]int{"/users": 1}, r.Routes())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	// registered after the Init: initialized, collected and served
+	orders := &handler.Handler{Path: "/orders"}
+	require.NoError(t, c.RegisterNamed("orders", orders))
+	assert.Equal(t, map[string]int{"/users": 1, "/orders": 1}, r.Routes())
+	assert.Equal(t, 1, orders.Serves())
+	assert.Contains(t, c.Plugins(), "orders")
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_DynamicFailure(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	r := &router.Router{}
+	users := &handler.Handler{Path: "/users"}
+	require.NoError(t, c.RegisterAll(r, users))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	users.Fail()
+
+	select {
+	case e := <-res:
+		require.Error(t, e.Error)
+	case <-time.After(time.Second * 5):
+		t.Fatal("no error from the failed plugin")
+	}
+
+	// failed plugin left the active set
+	assert.Empty(t, r.Routes())
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_DynamicRestart(t *testing.T) {
+	c := endure.New(slog.LevelDebug,
+		endure.DefaultRestartPolicy(&endure.RestartPolicy{Mode: endure.RestartOnFailure, InitialBackoff: time.Millisecond}),
+	)
+
+	r := &router.Router{}
+	users := &handler.Handler{Path: "/users"}
+	require.NoError(t, c.RegisterAll(r, users))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	users.Fail()
+
+	require.Eventually(t, func() bool {
+		return users.Serves() == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	// uncollected on the restart and collected again
+	require.Eventually(t, func() bool {
+		return r.Uncollected() == 1 && r.Routes()["/users"] == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_DynamicRestartCollector(t *testing.T) {
+	users := &handler.Handler{Path: "/users"}
+	c := endure.New(slog.LevelDebug,
+		endure.PluginRestartPolicy(users, &endure.RestartPolicy{Mode: endure.RestartOnFailure, InitialBackoff: time.Millisecond, RestartDependents: true}),
+	)
+
+	r := &router.Router{}
+	require.NoError(t, c.RegisterAll(r, users))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	users.Fail()
+
+	require.Eventually(t, func() bool {
+		return users.Serves() == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	// router is restarted as the dependent, but keeps the collected handler
+	require.Eventually(t, func() bool {
+		return r.Uncollected() == 1 && r.Routes()["/users"] == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_DynamicJoinFailure(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	r := &router.Router{}
+	users := &handler.Handler{Path: "/users"}
+	require.NoError(t, c.RegisterAll(r, users))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	// collector rejects the handler
+	a := &audit.Audit{}
+	require.Error(t, c.Register(a))
+	assert.Equal(t, 1, a.Calls())
+	assert.Equal(t, endure.DisabledJoinFailed, c.Why(a).Kind)
+	assert.NotContains(t, c.Plugins(), "*audit.Audit")
+
+	// discarded collector is not notified anymore
+	orders := &handler.Handler{Path: "/orders"}
+	require.NoError(t, c.RegisterNamed("orders", orders))
+	assert.Equal(t, 1, a.Calls())
+	assert.Equal(t, map[string]int{"/users": 1, "/orders": 1}, r.Routes())
+
+	require.NoError(t, c.Stop())
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Handler serves the route and might fail
+type Handler struct {
+	Path string
+
+	mu     sync.Mutex
+	errCh  chan error
+	serves int
+}
+
+func (h *Handler) Init() error {
+	return nil
+}
+
+func (h *Handler) Route() string {
+	return h.Path
+}
+
+func (h *Handler) Serve() chan error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.errCh = make(chan error, 1)
+	h.serves++
+	return h.errCh
+}
+
+func (h *Handler) Stop(context.Context) error {
+	return nil
+}
+
+func (h *Handler) Fail() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.errCh <- errors.New("handler failed")
+}
+
+func (h *Handler) Serves() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.serves
+}
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"sync"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Handler interface {
+	Route() string
+}
+
+// Router keeps the set of the running handlers
+type Router struct {
+	mu          sync.Mutex
+	handlers    map[string]int
+	uncollected int
+}
+
+func (r *Router) Init() error {
+	r.handlers = make(map[string]int)
+	return nil
+}
+
+func (r *Router) Collects() []*dep.In {
+	return []*dep.In{
+		dep.Fits(func(p any) {
+			r.mu.Lock()
+			r.handlers[p.(Handler).Route()]++
+			r.mu.Unlock()
+		}, (*Handler)(nil)).WithUncollect(func(p any, _ *dep.Source) {
+			r.mu.Lock()
+			r.uncollected++
+			r.handlers[p.(Handler).Route()]--
+			if r.handlers[p.(Handler).Route()] == 0 {
+				delete(r.handlers, p.(Handler).Route())
+			}
+			r.mu.Unlock()
+		}),
+	}
+}
+
+func (r *Router) Routes() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make(map[string]int, len(r.handlers))
+	for k, v := range r.handlers {
+		res[k] = v
+	}
+
+	return res
+}
+
+func (r *Router) Uncollected() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.uncollected
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/endure/v2/tests/events/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/events/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/events/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []*endure.Event
+}
+
+func (r *recorder) OnEvent(event *endure.Event) {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+}
+
+// types returns the events types of the plugin
+func (r *recorder) types(plugin string) []endure.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []endure.EventType
+	for _, ev := range r.events {
+		if ev.Plugin == plugin {
+			res = append(res, ev.Type)
+		}
+	}
+
+	return res
+}
+
+func (r *recorder) find(tp endure.EventType, plugin string) *endure.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ev := range r.events {
+		if ev.Type == tp && ev.Plugin == plugin {
+			return ev
+		}
+	}
+
+	return nil
+}
+
+func TestEndure_Events(t *testing.T) {
+	rec := &recorder{}
+	c := endure.New(slog.LevelDebug, endure.Observe(rec))
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, &plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+	require.NoError(t, c.Stop())
+
+	assert.Equal(t, []endure.EventType{
+		endure.EventRegistered,
+		endure.EventInitStarted,
+		endure.EventInitFinished,
+		endure.EventServeStarted,
+		endure.EventStopStarted,
+		endure.EventStopFinished,
+	}, rec.types("*plugin2.Plugin2"))
+
+	edge := rec.find(endure.EventEdgeResolved, "*plugin1.Plugin1")
+	require.NotNil(t, edge)
+	assert.Equal(t, "*plugin2.Plugin2", edge.Dest)
+	assert.Equal(t, graph.InitConnection, edge.Edge)
+
+	disabled := rec.find(endure.EventDisabled, "*plugin3.Plugin3")
+	require.NotNil(t, disabled)
+	assert.NotEmpty(t, disabled.Reason)
+
+	finished := rec.find(endure.EventInitFinished, "*plugin3.Plugin3")
+	require.NotNil(t, finished)
+	assert.Error(t, finished.Error)
+}
@@ -0,0 +1,24 @@
+package plugin2
+
+import (
+	"context"
+)
+
+type DB interface {
+	Query() string
+}
+
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init(DB) error {
+	return nil
+}
+
+func (p *Plugin2) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin2) Stop(context.Context) error {
+	return nil
+}
@@ -0,0 +1,12 @@
+package plugin3
+
+import (
+	"github.com/roadrunner-server/errors"
+)
+
+type Plugin3 struct {
+}
+
+func (p *Plugin3) Init() error {
+	return errors.E(errors.Op("plugin3_init"), errors.Disabled)
+}
@@ -1,6 +1,7 @@
 package happy_scenarios
 
 import (
+	"bytes"
 	"log/slog"
 	"testing"
 	"time"
@@ -17,6 +18,8 @@ import (
 	plugin12 "github.com/roadrunner-server/endure/v2/tests/happy_scenarios/provided_value_but_need_pointer/plugin1"
 	plugin22 "github.com/roadrunner-server/endure/v2/tests/happy_scenarios/provided_value_but_need_pointer/plugin2"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestEndure_DifferentLogLevels(t *testing.T) {
@@ -53,6 +56,50 @@ func testLog(t *testing.T, level slog.Leveler) {
 	assert.NoError(t, c.Stop())
 }
 
+func TestEndure_ParallelInit(t *testing.T) {
+	plugins := func() []any {
+		return []any{
+			&plugin4.S4{},
+			&plugin2.S2{},
+			&plugin3.S3{},
+			&plugin1.S1{},
+			&plugin5.S5{},
+			&plugin6.S6Interface{},
+		}
+	}
+
+	seq := endure.New(slog.LevelDebug)
+	assert.NoError(t, seq.RegisterAll(plugins()...))
+	assert.NoError(t, seq.Init())
+
+	c := endure.New(slog.LevelDebug, endure.ParallelInit(4))
+	assert.NoError(t, c.RegisterAll(plugins()...))
+	assert.NoError(t, c.Init())
+	assert.Equal(t, seq.Plugins(), c.Plugins())
+
+	_, err := c.Serve()
+	assert.NoError(t, err)
+	assert.NoError(t, c.Stop())
+}
+
+func TestEndure_SlogHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := endure.New(nil, endure.SlogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	assert.NoError(t, c.Register(&plugin4.S4{}))
+	assert.Contains(t, buf.String(), `"msg":"type registered"`)
+	assert.Contains(t, buf.String(), `"logger":"endure"`)
+	assert.Contains(t, buf.String(), `"type":"plugin4.S4"`)
+}
+
+func TestEndure_ZapLogger(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	c := endure.New(nil, endure.ZapLogger(zap.New(core)))
+
+	assert.NoError(t, c.Register(&plugin4.S4{}))
+	assert.NotZero(t, logs.FilterMessage("type registered").FilterLoggerName("endure").Len())
+}
+
 func TestEndure_Init_OK(t *testing.T) {
 	c := endure.New(slog.LevelDebug)
 
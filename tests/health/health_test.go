@@ -0,0 +1,129 @@
+package health
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/health/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/health/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/health/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_Ready(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	// not served yet
+	assert.False(t, c.Ready().OK)
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	rep := c.Ready()
+	assert.True(t, rep.OK)
+	require.Len(t, rep.Plugins, 1)
+	assert.Equal(t, "*plugin1.Plugin1", rep.Plugins[0].Plugin)
+
+	assert.True(t, c.Health().OK)
+	require.NoError(t, c.Stop())
+	assert.False(t, c.Ready().OK)
+}
+
+func TestEndure_HealthProbes(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.HealthProbes("127.0.0.1:18077"))
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 100)
+
+	resp, err := http.Get("http://127.0.0.1:18077/ready")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	resp, err = http.Get("http://127.0.0.1:18077/health")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	rep := &endure.HealthReport{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(rep))
+	_ = resp.Body.Close()
+
+	assert.False(t, rep.OK)
+	require.Len(t, rep.Plugins, 2)
+
+	for _, p := range rep.Plugins {
+		if p.Plugin == "*plugin2.Plugin2" {
+			assert.False(t, p.OK)
+			assert.Equal(t, "connection lost", p.Details)
+		}
+	}
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_HealthProbesLifecycle(t *testing.T) {
+	// address is already in use
+	ln, err := net.Listen("tcp", "127.0.0.1:18079")
+	require.NoError(t, err)
+
+	c := endure.New(slog.LevelDebug, endure.HealthProbes("127.0.0.1:18079"))
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.Error(t, err)
+	require.NoError(t, ln.Close())
+
+	c = endure.New(slog.LevelDebug, endure.HealthProbes("127.0.0.1:18079"))
+	require.NoError(t, c.Register(&plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.NoError(t, err)
+
+	resp, err := http.Get("http://127.0.0.1:18079/health")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.NoError(t, c.Stop())
+
+	// probes server is shut down on Stop
+	_, err = http.Get("http://127.0.0.1:18079/health")
+	require.Error(t, err)
+}
+
+func TestEndure_HealthDuringInit(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin3.Plugin3{Delay: time.Second}))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.Init()
+	}()
+
+	time.Sleep(time.Millisecond * 100)
+
+	// probes do not wait for the Init
+	start := time.Now()
+	assert.True(t, c.Health().OK)
+	assert.False(t, c.Ready().OK)
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+
+	require.NoError(t, <-errCh)
+	require.Len(t, c.Health().Plugins, 1)
+}
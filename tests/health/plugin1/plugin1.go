@@ -0,0 +1,34 @@
+package plugin1
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/roadrunner-server/endure/v2"
+)
+
+// Plugin1 is ready after the Serve
+type Plugin1 struct {
+	served atomic.Bool
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	p.served.Store(true)
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	return nil
+}
+
+func (p *Plugin1) Ready() *endure.Status {
+	return &endure.Status{OK: p.served.Load()}
+}
+
+func (p *Plugin1) Health() *endure.Status {
+	return &endure.Status{OK: true}
+}
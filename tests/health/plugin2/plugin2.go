@@ -0,0 +1,17 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/endure/v2"
+)
+
+// Plugin2 is not alive
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init() error {
+	return nil
+}
+
+func (p *Plugin2) Health() *endure.Status {
+	return &endure.Status{OK: false, Details: "connection lost"}
+}
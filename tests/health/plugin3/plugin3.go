@@ -0,0 +1,15 @@
+package plugin3
+
+import (
+	"time"
+)
+
+// Plugin3 has the slow Init
+type Plugin3 struct {
+	Delay time.Duration
+}
+
+func (p *Plugin3) Init() error {
+	time.Sleep(p.Delay)
+	return nil
+}
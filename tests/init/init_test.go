@@ -7,10 +7,18 @@ import (
 	"time"
 
 	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin10"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin11"
 	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin2"
 	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin3"
 	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin4"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin5"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin6"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin7"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin8"
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEndure_MainThread_Serve(t *testing.T) {
@@ -74,3 +82,71 @@ func TestEndure_Init(t *testing.T) {
 
 	assert.Error(t, c.Stop())
 }
+
+func TestEndure_InitContext(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.InitTimeout(time.Second))
+
+	assert.NoError(t, c.RegisterAll(&plugin6.Plugin6{}, &plugin7.Plugin7{}))
+	assert.NoError(t, c.Init())
+	assert.Equal(t, []string{"*plugin7.Plugin7", "*plugin6.Plugin6"}, c.Plugins())
+}
+
+func TestEndure_InitTimeout(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.InitTimeout(time.Minute))
+
+	assert.NoError(t, c.RegisterAll(&plugin5.Plugin5{}, &plugin7.Plugin7{}))
+
+	start := time.Now()
+	err := c.Init()
+	require.Error(t, err)
+	// per-plugin timeout overrides the container-wide one
+	assert.Less(t, time.Since(start), time.Second*10)
+	assert.Contains(t, err.Error(), "*plugin5.Plugin5")
+}
+
+func TestEndure_InitTimeoutProviders(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	assert.NoError(t, c.RegisterAll(&plugin8.Plugin8{}, &plugin9.Plugin9{}))
+
+	// provider and Init fit the timeout separately, but not together
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "*plugin9.Plugin9")
+}
+
+func TestEndure_ParallelInitTimeout(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.InitTimeout(time.Second), endure.ParallelInit(1))
+
+	// more plugins than workers, the time spent waiting for the worker is not counted
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, c.RegisterNamed(name, &plugin10.Plugin10{Delay: time.Millisecond * 600}))
+	}
+
+	require.NoError(t, c.Init())
+	assert.Len(t, c.Plugins(), 3)
+}
+
+func TestEndure_InitTimeoutPanic(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.InitTimeout(time.Second))
+
+	require.NoError(t, c.Register(&plugin11.Plugin11{}))
+
+	// Init is called in the separate goroutine, the panic is returned as the error
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "*plugin11.Plugin11")
+	assert.Contains(t, err.Error(), "plugin11 is broken")
+}
+
+func TestEndure_ParallelInitPanic(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.ParallelInit(2))
+
+	require.NoError(t, c.RegisterAll(&plugin11.Plugin11{}, &plugin10.Plugin10{}))
+
+	// Init is called by the worker, the panic is returned as the error
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "*plugin11.Plugin11")
+	assert.Contains(t, err.Error(), "plugin11 is broken")
+}
@@ -0,0 +1,15 @@
+package plugin10
+
+import (
+	"time"
+)
+
+// Plugin10 has the slow Init
+type Plugin10 struct {
+	Delay time.Duration
+}
+
+func (p *Plugin10) Init() error {
+	time.Sleep(p.Delay)
+	return nil
+}
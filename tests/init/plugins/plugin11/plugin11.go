@@ -0,0 +1,9 @@
+package plugin11
+
+// Plugin11 panics in the Init
+type Plugin11 struct {
+}
+
+func (p *Plugin11) Init() error {
+	panic("plugin11 is broken")
+}
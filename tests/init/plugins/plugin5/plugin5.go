@@ -0,0 +1,18 @@
+package plugin5
+
+import (
+	"context"
+	"time"
+)
+
+// Plugin5 is stuck in the Init and ignores the context
+type Plugin5 struct {
+}
+
+func (f *Plugin5) Init(context.Context) error {
+	select {}
+}
+
+func (f *Plugin5) InitTimeout() time.Duration {
+	return time.Millisecond * 100
+}
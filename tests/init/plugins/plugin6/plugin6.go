@@ -0,0 +1,33 @@
+package plugin6
+
+import (
+	"context"
+	"errors"
+
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin7"
+)
+
+type Plugin6 struct {
+	db plugin7.DB
+}
+
+func (f *Plugin6) Init(ctx context.Context, db plugin7.DB) error {
+	if ctx == nil {
+		return errors.New("context should be injected")
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("context should have a deadline")
+	}
+
+	f.db = db
+	return db.Dial(ctx)
+}
+
+func (f *Plugin6) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (f *Plugin6) Stop(context.Context) error {
+	return nil
+}
@@ -0,0 +1,20 @@
+package plugin7
+
+import (
+	"context"
+)
+
+type DB interface {
+	Dial(ctx context.Context) error
+}
+
+type Plugin7 struct {
+}
+
+func (f *Plugin7) Init() error {
+	return nil
+}
+
+func (f *Plugin7) Dial(ctx context.Context) error {
+	return ctx.Err()
+}
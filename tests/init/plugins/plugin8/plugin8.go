@@ -0,0 +1,37 @@
+package plugin8
+
+import (
+	"context"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Conn interface {
+	Query() string
+}
+
+type conn struct{}
+
+func (c *conn) Query() string {
+	return "ok"
+}
+
+// Plugin8 provides the slow connection
+type Plugin8 struct {
+}
+
+func (p *Plugin8) Init() error {
+	return nil
+}
+
+func (p *Plugin8) Connect(context.Context) (Conn, error) {
+	time.Sleep(time.Millisecond * 400)
+	return &conn{}, nil
+}
+
+func (p *Plugin8) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.Bind((*Conn)(nil), p.Connect),
+	}
+}
@@ -0,0 +1,25 @@
+package plugin9
+
+import (
+	"context"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/tests/init/plugins/plugin8"
+)
+
+// Plugin9 has the slow Init and the slow provider, which share the Init timeout
+type Plugin9 struct {
+}
+
+func (p *Plugin9) Init(ctx context.Context, _ plugin8.Conn) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Millisecond * 400):
+		return nil
+	}
+}
+
+func (p *Plugin9) InitTimeout() time.Duration {
+	return time.Millisecond * 600
+}
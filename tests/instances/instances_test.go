@@ -0,0 +1,65 @@
+package instances
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/instances/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/instances/plugin2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_Instances(t *testing.T) {
+	var registered []string
+	c := endure.New(slog.LevelDebug, endure.Observe(endure.ObserverFunc(func(ev *endure.Event) {
+		if ev.Type == endure.EventRegistered {
+			registered = append(registered, ev.Plugin)
+		}
+	})))
+
+	cache := &plugin1.Plugin1{DB: 1}
+	sessions := &plugin1.Plugin1{DB: 2}
+	p2 := &plugin2.Plugin2{}
+
+	require.NoError(t, c.RegisterNamed("cache", cache))
+	require.NoError(t, c.RegisterNamed("sessions", sessions))
+	require.NoError(t, c.Register(p2))
+	require.NoError(t, c.Init())
+
+	assert.Equal(t, []string{"*plugin1.Plugin1#cache", "*plugin1.Plugin1#sessions", "*plugin2.Plugin2"}, registered)
+	assert.ElementsMatch(t, []string{"cache", "sessions", "*plugin2.Plugin2"}, c.Plugins())
+
+	require.NotNil(t, p2.Sessions())
+	assert.Equal(t, 2, p2.Sessions().Get(""))
+	assert.Len(t, p2.All(), 2)
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+	assert.True(t, cache.Served())
+	assert.True(t, sessions.Served())
+
+	report, err := c.StopWithReport()
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(report))
+	for i := range report {
+		ids = append(ids, report[i].VertexID)
+	}
+	assert.ElementsMatch(t, []string{"*plugin1.Plugin1#cache", "*plugin1.Plugin1#sessions"}, ids)
+}
+
+func TestEndure_InstanceDuplicate(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.RegisterNamed("cache", &plugin1.Plugin1{DB: 1}))
+	// same instance name is ignored
+	require.NoError(t, c.RegisterNamed("cache", &plugin1.Plugin1{DB: 2}))
+	// unnamed plugin of the same type is a different instance
+	require.NoError(t, c.Register(&plugin1.Plugin1{DB: 3}))
+	require.Error(t, c.RegisterNamed("", &plugin1.Plugin1{}))
+	require.NoError(t, c.Init())
+
+	assert.ElementsMatch(t, []string{"cache", "*plugin1.Plugin1"}, c.Plugins())
+}
@@ -0,0 +1,32 @@
+package plugin1
+
+import (
+	"context"
+)
+
+// Plugin1 is the redis-like plugin, registered several times with different instance names
+type Plugin1 struct {
+	DB     int
+	served bool
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	p.served = true
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	return nil
+}
+
+func (p *Plugin1) Get(string) int {
+	return p.DB
+}
+
+func (p *Plugin1) Served() bool {
+	return p.served
+}
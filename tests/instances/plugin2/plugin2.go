@@ -0,0 +1,35 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Storage interface {
+	Get(key string) int
+}
+
+// Plugin2 uses the sessions storage and sees all storages
+type Plugin2 struct {
+	sessions Storage
+	all      []Storage
+}
+
+func (p *Plugin2) Init(sessions Storage, all []Storage) error {
+	p.sessions = sessions
+	p.all = all
+	return nil
+}
+
+func (p *Plugin2) Qualifiers() []*dep.Qualifier {
+	return []*dep.Qualifier{
+		dep.Named((*Storage)(nil), "sessions"),
+	}
+}
+
+func (p *Plugin2) Sessions() Storage {
+	return p.sessions
+}
+
+func (p *Plugin2) All() []Storage {
+	return p.all
+}
@@ -0,0 +1,132 @@
+package introspect
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/endure/v2/tests/introspect/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/introspect/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/introspect/plugin3"
+	"github.com/roadrunner-server/endure/v2/tests/introspect/plugin4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_State(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := endure.New(slog.LevelDebug, endure.AdminServer(&endure.AdminConfig{Listener: ln}))
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, &plugin3.Plugin3{}, &plugin4.Plugin4{}))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	select {
+	case r := <-res:
+		assert.Equal(t, "*plugin4.Plugin4", r.VertexID)
+	case <-time.After(time.Second * 5):
+		t.Fatal("plugin4 should fail")
+	}
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/debug/endure/state")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	state := &endure.State{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(state))
+	_ = resp.Body.Close()
+
+	plugins := make(map[string]*endure.PluginState, len(state.Plugins))
+	for _, p := range state.Plugins {
+		plugins[p.Plugin] = p
+	}
+	require.Len(t, plugins, 4)
+
+	assert.True(t, plugins["*plugin1.Plugin1"].Active)
+	assert.Equal(t, []string{"plugin1.DB"}, plugins["*plugin1.Plugin1"].Provides)
+	assert.Equal(t, uint(5), plugins["*plugin2.Plugin2"].Weight)
+
+	assert.False(t, plugins["*plugin3.Plugin3"].Active)
+	require.NotNil(t, plugins["*plugin3.Plugin3"].Reason)
+	assert.Equal(t, endure.DisabledMissingDependency, plugins["*plugin3.Plugin3"].Reason.Kind)
+	assert.Equal(t, []string{"plugin3.Cache"}, plugins["*plugin3.Plugin3"].Reason.Missing)
+
+	assert.Contains(t, plugins["*plugin4.Plugin4"].LastError, "plugin4 failed")
+
+	assert.NotContains(t, state.Order, "*plugin3.Plugin3")
+	assert.Less(t, slices.Index(state.Order, "*plugin1.Plugin1"), slices.Index(state.Order, "*plugin2.Plugin2"))
+
+	assert.Contains(t, state.Edges, &endure.EdgeState{
+		Src:  "*plugin1.Plugin1",
+		Dest: "*plugin2.Plugin2",
+		Type: graph.InitConnection,
+		Via:  "plugin2.DB",
+	})
+
+	// control endpoints are disabled by default
+	resp, err = http.Post("http://"+ln.Addr().String()+"/debug/endure/plugins/*plugin1.Plugin1/stop", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_Control(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := endure.New(slog.LevelDebug, endure.AdminServer(&endure.AdminConfig{Listener: ln, Control: true}))
+
+	p1 := &plugin1.Plugin1{}
+	p2 := &plugin2.Plugin2{}
+	require.NoError(t, c.RegisterAll(p1, p2))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.NoError(t, err)
+
+	addr := "http://" + ln.Addr().String() + "/debug/endure/plugins/"
+
+	// dependents are stopped as well
+	resp, err := http.Post(addr+"*plugin1.Plugin1/stop", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, int64(1), p1.Stops())
+	assert.Equal(t, int64(1), p2.Stops())
+
+	for _, p := range c.PluginStates() {
+		assert.True(t, p.Stopped, p.Plugin)
+	}
+
+	resp, err = http.Post(addr+"*plugin1.Plugin1/restart", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, int64(2), p1.Serves())
+	assert.Equal(t, int64(2), p2.Serves())
+
+	resp, err = http.Post(addr+"*plugin9.Plugin9/restart", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	require.NoError(t, c.Stop())
+
+	// stopped once per serve
+	assert.Equal(t, int64(2), p1.Stops())
+	assert.Equal(t, int64(2), p2.Stops())
+}
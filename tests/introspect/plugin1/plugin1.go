@@ -0,0 +1,54 @@
+package plugin1
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type DB interface {
+	Query() string
+}
+
+type db struct{}
+
+func (d *db) Query() string {
+	return "plugin1"
+}
+
+// Plugin1 provides the database
+type Plugin1 struct {
+	serves atomic.Int64
+	stops  atomic.Int64
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	p.serves.Add(1)
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	p.stops.Add(1)
+	return nil
+}
+
+func (p *Plugin1) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.Provide(func() DB {
+			return &db{}
+		}),
+	}
+}
+
+func (p *Plugin1) Serves() int64 {
+	return p.serves.Load()
+}
+
+func (p *Plugin1) Stops() int64 {
+	return p.stops.Load()
+}
@@ -0,0 +1,42 @@
+package plugin2
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type DB interface {
+	Query() string
+}
+
+// Plugin2 depends on the database
+type Plugin2 struct {
+	serves atomic.Int64
+	stops  atomic.Int64
+}
+
+func (p *Plugin2) Init(DB) error {
+	return nil
+}
+
+func (p *Plugin2) Serve() chan error {
+	p.serves.Add(1)
+	return make(chan error, 1)
+}
+
+func (p *Plugin2) Stop(context.Context) error {
+	p.stops.Add(1)
+	return nil
+}
+
+func (p *Plugin2) Weight() uint {
+	return 5
+}
+
+func (p *Plugin2) Serves() int64 {
+	return p.serves.Load()
+}
+
+func (p *Plugin2) Stops() int64 {
+	return p.stops.Load()
+}
@@ -0,0 +1,12 @@
+package plugin3
+
+type Cache interface {
+	Get(key string) string
+}
+
+// Plugin3 depends on the cache, which is not registered
+type Plugin3 struct{}
+
+func (p *Plugin3) Init(Cache) error {
+	return nil
+}
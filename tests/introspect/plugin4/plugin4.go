@@ -0,0 +1,28 @@
+package plugin4
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Plugin4 fails shortly after the Serve
+type Plugin4 struct{}
+
+func (p *Plugin4) Init() error {
+	return nil
+}
+
+func (p *Plugin4) Serve() chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		errCh <- errors.New("plugin4 failed")
+	}()
+
+	return errCh
+}
+
+func (p *Plugin4) Stop(context.Context) error {
+	return nil
+}
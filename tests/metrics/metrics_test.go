@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/metrics/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/metrics/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/metrics/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_Metrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c := endure.New(slog.LevelDebug, endure.Metrics(reg))
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, &plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	select {
+	case r := <-res:
+		t.Fatalf("error should be handled by the supervisor, got: %v", r.Error)
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	require.NoError(t, c.Stop())
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	names := make(map[string]struct{}, len(families))
+	for _, f := range families {
+		names[f.GetName()] = struct{}{}
+	}
+
+	for _, name := range []string{
+		"endure_init_duration_seconds",
+		"endure_serve_duration_seconds",
+		"endure_stop_duration_seconds",
+		"endure_vertices",
+		"endure_serve_errors_total",
+		"endure_restarts_total",
+	} {
+		assert.Contains(t, names, name)
+	}
+
+	// plugin1 and plugin3 are active, plugin2 returned errors.Disabled
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "endure_vertices"))
+	assert.Equal(t, 3, testutil.CollectAndCount(reg, "endure_init_duration_seconds"))
+	// plugin1 was served twice
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "endure_serve_duration_seconds"))
+
+	expected := `
+# HELP endure_restarts_total Number of the plugin restarts made by the supervisor.
+# TYPE endure_restarts_total counter
+endure_restarts_total{plugin="*plugin1.Plugin1"} 1
+# HELP endure_serve_errors_total Number of the errors sent by the plugin to the Serve channel.
+# TYPE endure_serve_errors_total counter
+endure_serve_errors_total{plugin="*plugin1.Plugin1"} 1
+# HELP endure_vertices Number of the registered plugins by the state (active or disabled).
+# TYPE endure_vertices gauge
+endure_vertices{state="active"} 2
+endure_vertices{state="disabled"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "endure_restarts_total", "endure_serve_errors_total", "endure_vertices"))
+}
+
+func TestEndure_MetricsServer(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.MetricsServer("127.0.0.1:18078"))
+
+	require.NoError(t, c.Register(&plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 100)
+
+	resp, err := http.Get("http://127.0.0.1:18078/metrics")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Contains(t, string(body), `endure_init_duration_seconds_count{plugin="*plugin3.Plugin3"} 1`)
+	assert.Contains(t, string(body), `endure_vertices{state="active"} 1`)
+
+	require.NoError(t, c.Stop())
+
+	// metrics server is shut down on Stop
+	_, err = http.Get("http://127.0.0.1:18078/metrics")
+	require.Error(t, err)
+}
+
+func TestEndure_MetricsServerBindError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = ln.Close()
+	}()
+
+	c := endure.New(slog.LevelDebug, endure.MetricsServer(ln.Addr().String()))
+
+	require.NoError(t, c.Register(&plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	_, err = c.Serve()
+	require.Error(t, err)
+}
+
+func TestEndure_MetricsAlreadyRegistered(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	c1 := endure.New(slog.LevelDebug, endure.Metrics(reg))
+	require.NoError(t, c1.Register(&plugin3.Plugin3{}))
+	require.NoError(t, c1.Init())
+	_, err := c1.Serve()
+	require.NoError(t, err)
+
+	// the second container's metrics clash with the first one's
+	c2 := endure.New(slog.LevelDebug, endure.Metrics(reg))
+	require.NoError(t, c2.Register(&plugin3.Plugin3{}))
+	require.NoError(t, c2.Init())
+	_, err = c2.Serve()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate metrics collector registration attempted")
+
+	require.NoError(t, c1.Stop())
+}
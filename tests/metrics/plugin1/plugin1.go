@@ -0,0 +1,42 @@
+package plugin1
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+)
+
+// Plugin1 fails on the first Serve only
+type Plugin1 struct {
+	serves atomic.Int64
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	errCh := make(chan error, 1)
+	if p.serves.Add(1) == 1 {
+		go func() {
+			time.Sleep(time.Millisecond * 50)
+			errCh <- errors.New("plugin1 failed")
+		}()
+	}
+
+	return errCh
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	return nil
+}
+
+func (p *Plugin1) RestartPolicy() *endure.RestartPolicy {
+	return &endure.RestartPolicy{
+		Mode:           endure.RestartOnFailure,
+		InitialBackoff: time.Millisecond * 10,
+	}
+}
@@ -0,0 +1,22 @@
+package plugin2
+
+import (
+	"context"
+
+	"github.com/roadrunner-server/errors"
+)
+
+type Plugin2 struct{}
+
+func (p *Plugin2) Init() error {
+	const op = errors.Op("plugin2_init")
+	return errors.E(op, errors.Disabled)
+}
+
+func (p *Plugin2) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin2) Stop(context.Context) error {
+	return nil
+}
@@ -0,0 +1,19 @@
+package plugin3
+
+import (
+	"context"
+)
+
+type Plugin3 struct{}
+
+func (p *Plugin3) Init() error {
+	return nil
+}
+
+func (p *Plugin3) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin3) Stop(context.Context) error {
+	return nil
+}
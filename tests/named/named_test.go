@@ -0,0 +1,66 @@
+package named
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/named/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/named/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/named/plugin3"
+	"github.com/roadrunner-server/endure/v2/tests/named/plugin4"
+	"github.com/roadrunner-server/endure/v2/tests/named/plugin5"
+	"github.com/roadrunner-server/endure/v2/tests/named/plugin6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_NamedPlugin(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p3 := &plugin3.Plugin3{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, p3))
+	require.NoError(t, c.Init())
+
+	// local storage has lower weight, but it is requested explicitly
+	require.NotNil(t, p3.Storage)
+	assert.Equal(t, "local", p3.Storage.Kind())
+}
+
+func TestEndure_NamedProvided(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p5 := &plugin5.Plugin5{}
+	require.NoError(t, c.RegisterAll(&plugin4.Plugin4{}, p5))
+	require.NoError(t, c.Init())
+
+	require.NotNil(t, p5.Storage)
+	assert.Equal(t, "replica", p5.Storage.Kind())
+}
+
+func TestEndure_NamedMissing(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p3 := &plugin3.Plugin3{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, p3))
+	require.NoError(t, c.Init())
+
+	// there is no local storage, plugin disabled
+	assert.Equal(t, []string{"s3"}, c.Plugins())
+}
+
+func TestEndure_Ambiguity(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p6 := &plugin6.Plugin6{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, p6))
+	require.NoError(t, c.Init())
+	assert.Equal(t, "s3", p6.Storage.Kind())
+
+	c = endure.New(slog.LevelDebug, endure.OnAmbiguity(endure.AmbiguityFail))
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, &plugin6.Plugin6{}))
+
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.Storage")
+}
@@ -0,0 +1,21 @@
+package plugin1
+
+// Plugin1 is the s3 storage
+type Plugin1 struct {
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Kind() string {
+	return "s3"
+}
+
+func (p *Plugin1) Name() string {
+	return "s3"
+}
+
+func (p *Plugin1) Weight() uint {
+	return 10
+}
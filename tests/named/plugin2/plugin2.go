@@ -0,0 +1,17 @@
+package plugin2
+
+// Plugin2 is the local storage
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init() error {
+	return nil
+}
+
+func (p *Plugin2) Kind() string {
+	return "local"
+}
+
+func (p *Plugin2) Name() string {
+	return "local"
+}
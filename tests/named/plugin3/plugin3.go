@@ -0,0 +1,22 @@
+package plugin3
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/named/storage"
+)
+
+// Plugin3 needs the local storage
+type Plugin3 struct {
+	Storage storage.Storage
+}
+
+func (p *Plugin3) Init(s storage.Storage) error {
+	p.Storage = s
+	return nil
+}
+
+func (p *Plugin3) Qualifiers() []*dep.Qualifier {
+	return []*dep.Qualifier{
+		dep.Named((*storage.Storage)(nil), "local"),
+	}
+}
@@ -0,0 +1,29 @@
+package plugin4
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/named/storage"
+)
+
+// Plugin4 provides primary and replica storages
+type Plugin4 struct {
+}
+
+func (p *Plugin4) Init() error {
+	return nil
+}
+
+func (p *Plugin4) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.BindNamed("primary", (*storage.Storage)(nil), p.Primary),
+		dep.BindNamed("replica", (*storage.Storage)(nil), p.Replica),
+	}
+}
+
+func (p *Plugin4) Primary() *storage.Value {
+	return &storage.Value{K: "primary"}
+}
+
+func (p *Plugin4) Replica() *storage.Value {
+	return &storage.Value{K: "replica"}
+}
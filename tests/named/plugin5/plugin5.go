@@ -0,0 +1,22 @@
+package plugin5
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/named/storage"
+)
+
+// Plugin5 needs the replica storage
+type Plugin5 struct {
+	Storage storage.Storage
+}
+
+func (p *Plugin5) Init(s storage.Storage) error {
+	p.Storage = s
+	return nil
+}
+
+func (p *Plugin5) Qualifiers() []*dep.Qualifier {
+	return []*dep.Qualifier{
+		dep.Named((*storage.Storage)(nil), "replica"),
+	}
+}
@@ -0,0 +1,15 @@
+package plugin6
+
+import (
+	"github.com/roadrunner-server/endure/v2/tests/named/storage"
+)
+
+// Plugin6 needs any storage
+type Plugin6 struct {
+	Storage storage.Storage
+}
+
+func (p *Plugin6) Init(s storage.Storage) error {
+	p.Storage = s
+	return nil
+}
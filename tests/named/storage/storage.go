@@ -0,0 +1,13 @@
+package storage
+
+type Storage interface {
+	Kind() string
+}
+
+type Value struct {
+	K string
+}
+
+func (v *Value) Kind() string {
+	return v.K
+}
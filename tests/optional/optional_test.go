@@ -0,0 +1,51 @@
+package optional
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/optional/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/optional/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/optional/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_OptionalMissing(t *testing.T) {
+	var missing []string
+	c := endure.New(slog.LevelDebug, endure.Observe(endure.ObserverFunc(func(ev *endure.Event) {
+		if ev.Type == endure.EventOptionalMissing {
+			missing = append(missing, ev.Reason)
+		}
+	})))
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.Register(p1))
+	require.NoError(t, c.Init())
+
+	assert.False(t, p1.HasMetrics())
+	assert.Equal(t, []string{"*plugin1.Plugin1"}, c.Plugins())
+	assert.Equal(t, []string{"plugin1.Metrics"}, missing)
+}
+
+func TestEndure_OptionalPresent(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.RegisterAll(p1, &plugin2.Plugin2{}))
+	require.NoError(t, c.Init())
+
+	assert.True(t, p1.HasMetrics())
+}
+
+func TestEndure_OptionalDisabled(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.RegisterAll(p1, &plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	assert.False(t, p1.HasMetrics())
+	assert.Equal(t, []string{"*plugin1.Plugin1"}, c.Plugins())
+}
@@ -0,0 +1,29 @@
+package plugin1
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Metrics interface {
+	Inc(name string)
+}
+
+// Plugin1 has an optional metrics sink
+type Plugin1 struct {
+	metrics Metrics
+}
+
+func (p *Plugin1) Init(m Metrics) error {
+	p.metrics = m
+	return nil
+}
+
+func (p *Plugin1) Optional() []*dep.Opt {
+	return []*dep.Opt{
+		dep.Optional((*Metrics)(nil)),
+	}
+}
+
+func (p *Plugin1) HasMetrics() bool {
+	return p.metrics != nil
+}
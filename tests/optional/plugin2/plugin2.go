@@ -0,0 +1,11 @@
+package plugin2
+
+// Plugin2 is the metrics sink
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init() error {
+	return nil
+}
+
+func (p *Plugin2) Inc(string) {}
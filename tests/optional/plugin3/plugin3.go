@@ -0,0 +1,15 @@
+package plugin3
+
+import (
+	"github.com/roadrunner-server/errors"
+)
+
+// Plugin3 is the disabled metrics sink
+type Plugin3 struct {
+}
+
+func (p *Plugin3) Init() error {
+	return errors.E(errors.Op("plugin3_init"), errors.Disabled)
+}
+
+func (p *Plugin3) Inc(string) {}
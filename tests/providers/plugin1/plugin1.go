@@ -0,0 +1,47 @@
+package plugin1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type DB interface {
+	DSN() string
+}
+
+type db struct {
+	dsn string
+}
+
+func (d *db) DSN() string {
+	return d.dsn
+}
+
+// Plugin1 provides the DB connection, which might fail
+type Plugin1 struct {
+	Fail bool
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Connect(ctx context.Context) (DB, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+
+	if p.Fail {
+		return nil, errors.New("connection refused")
+	}
+
+	return &db{dsn: "postgres"}, nil
+}
+
+func (p *Plugin1) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.Bind((*DB)(nil), p.Connect),
+	}
+}
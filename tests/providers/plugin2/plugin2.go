@@ -0,0 +1,19 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/endure/v2/tests/providers/plugin1"
+)
+
+// Plugin2 uses the DB connection
+type Plugin2 struct {
+	db plugin1.DB
+}
+
+func (p *Plugin2) Init(db plugin1.DB) error {
+	p.db = db
+	return nil
+}
+
+func (p *Plugin2) DB() plugin1.DB {
+	return p.db
+}
@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/providers/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/providers/plugin2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_ProviderWithContext(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p2 := &plugin2.Plugin2{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, p2))
+	require.NoError(t, c.Init())
+
+	require.NotNil(t, p2.DB())
+	assert.Equal(t, "postgres", p2.DB().DSN())
+}
+
+func TestEndure_ProviderError(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{Fail: true}, &plugin2.Plugin2{}))
+
+	err := c.Init()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "*plugin1.Plugin1")
+	assert.Contains(t, err.Error(), "*plugin2.Plugin2")
+	assert.Contains(t, err.Error(), "connection refused")
+}
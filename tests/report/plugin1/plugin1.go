@@ -0,0 +1,27 @@
+package plugin1
+
+import (
+	"context"
+	"time"
+)
+
+// Plugin1 is the slow database
+type Plugin1 struct {
+}
+
+func (p *Plugin1) Init() error {
+	time.Sleep(time.Millisecond * 50)
+	return nil
+}
+
+func (p *Plugin1) Query() string {
+	return "select"
+}
+
+func (p *Plugin1) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	return nil
+}
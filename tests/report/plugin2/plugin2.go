@@ -0,0 +1,18 @@
+package plugin2
+
+import (
+	"time"
+)
+
+type DB interface {
+	Query() string
+}
+
+// Plugin2 depends on the database
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init(DB) error {
+	time.Sleep(time.Millisecond * 30)
+	return nil
+}
@@ -0,0 +1,14 @@
+package plugin3
+
+import (
+	"time"
+)
+
+// Plugin3 has no dependencies
+type Plugin3 struct {
+}
+
+func (p *Plugin3) Init() error {
+	time.Sleep(time.Millisecond * 10)
+	return nil
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/report/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/report/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/report/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_Report(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.ParallelInit(0))
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}, &plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+	require.NoError(t, c.Stop())
+
+	report := c.Report()
+	require.Len(t, report.Plugins, 3)
+
+	timings := make(map[string]*endure.PluginTimings, len(report.Plugins))
+	for _, p := range report.Plugins {
+		timings[p.Plugin] = p
+	}
+
+	assert.GreaterOrEqual(t, timings["*plugin1.Plugin1"].Init, time.Millisecond*50)
+	assert.GreaterOrEqual(t, timings["*plugin2.Plugin2"].Init, time.Millisecond*30)
+	assert.Positive(t, timings["*plugin1.Plugin1"].ResolveEdges)
+	assert.Positive(t, timings["*plugin1.Plugin1"].Serve)
+	assert.Positive(t, timings["*plugin1.Plugin1"].Stop)
+
+	assert.Equal(t, []string{"*plugin1.Plugin1", "*plugin2.Plugin2"}, report.CriticalPath)
+	assert.GreaterOrEqual(t, report.CriticalPathDuration, time.Millisecond*80)
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+
+	decoded := &endure.Report{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, report.CriticalPath, decoded.CriticalPath)
+
+	table := report.String()
+	assert.Contains(t, table, "PLUGIN")
+	assert.Contains(t, table, "critical path")
+	assert.Contains(t, table, "*plugin1.Plugin1 -> *plugin2.Plugin2")
+}
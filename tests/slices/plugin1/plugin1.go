@@ -0,0 +1,28 @@
+package plugin1
+
+type Middleware interface {
+	Middleware() string
+}
+
+// Plugin1 receives all registered middleware
+type Plugin1 struct {
+	mdwr []Middleware
+}
+
+func (p *Plugin1) Init(mdwr []Middleware) error {
+	p.mdwr = mdwr
+	return nil
+}
+
+func (p *Plugin1) Middleware() []string {
+	names := make([]string, 0, len(p.mdwr))
+	for i := range p.mdwr {
+		names = append(names, p.mdwr[i].Middleware())
+	}
+
+	return names
+}
+
+func (p *Plugin1) Injected() bool {
+	return p.mdwr != nil
+}
@@ -0,0 +1,17 @@
+package plugin2
+
+// Plugin2 is the gzip middleware
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init() error {
+	return nil
+}
+
+func (p *Plugin2) Middleware() string {
+	return "gzip"
+}
+
+func (p *Plugin2) Weight() uint {
+	return 10
+}
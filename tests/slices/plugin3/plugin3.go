@@ -0,0 +1,34 @@
+package plugin3
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/slices/plugin1"
+)
+
+// Plugin3 provides the headers middleware
+type Plugin3 struct {
+}
+
+type headers struct{}
+
+func (h *headers) Middleware() string {
+	return "headers"
+}
+
+func (p *Plugin3) Init() error {
+	return nil
+}
+
+func (p *Plugin3) ProvideHeaders() plugin1.Middleware {
+	return &headers{}
+}
+
+func (p *Plugin3) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.Bind((*plugin1.Middleware)(nil), p.ProvideHeaders),
+	}
+}
+
+func (p *Plugin3) Weight() uint {
+	return 20
+}
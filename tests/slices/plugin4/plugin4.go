@@ -0,0 +1,39 @@
+package plugin4
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/slices/plugin1"
+)
+
+// Plugin4 provides the cors and auth middleware
+type Plugin4 struct {
+}
+
+type middleware string
+
+func (m middleware) Middleware() string {
+	return string(m)
+}
+
+func (p *Plugin4) Init() error {
+	return nil
+}
+
+func (p *Plugin4) ProvideCors() plugin1.Middleware {
+	return middleware("cors")
+}
+
+func (p *Plugin4) ProvideAuth() plugin1.Middleware {
+	return middleware("auth")
+}
+
+func (p *Plugin4) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.Bind((*plugin1.Middleware)(nil), p.ProvideCors),
+		dep.Bind((*plugin1.Middleware)(nil), p.ProvideAuth),
+	}
+}
+
+func (p *Plugin4) Weight() uint {
+	return 5
+}
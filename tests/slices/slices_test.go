@@ -0,0 +1,49 @@
+package slices
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/slices/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/slices/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/slices/plugin3"
+	"github.com/roadrunner-server/endure/v2/tests/slices/plugin4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_SliceAll(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.RegisterAll(p1, &plugin2.Plugin2{}, &plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	// ordered by weight
+	assert.Equal(t, []string{"headers", "gzip"}, p1.Middleware())
+	assert.Len(t, c.Plugins(), 3)
+}
+
+func TestEndure_SliceSeveralProviders(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.RegisterAll(p1, &plugin2.Plugin2{}, &plugin4.Plugin4{}))
+	require.NoError(t, c.Init())
+
+	// each provider method of the same plugin is injected
+	assert.Equal(t, []string{"gzip", "cors", "auth"}, p1.Middleware())
+}
+
+func TestEndure_SliceEmpty(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.Register(p1))
+	require.NoError(t, c.Init())
+
+	assert.True(t, p1.Injected())
+	assert.Empty(t, p1.Middleware())
+	assert.Equal(t, []string{"*plugin1.Plugin1"}, c.Plugins())
+}
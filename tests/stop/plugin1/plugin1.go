@@ -0,0 +1,48 @@
+package plugin1
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	mu      sync.Mutex
+	stopped []string
+)
+
+// Stopped records the plugin as stopped
+func Stopped(name string) {
+	mu.Lock()
+	stopped = append(stopped, name)
+	mu.Unlock()
+}
+
+// Order returns the stop order
+func Order() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]string(nil), stopped...)
+}
+
+// Plugin1 is the DB-like plugin
+type Plugin1 struct {
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	Stopped("plugin1")
+	return nil
+}
+
+func (p *Plugin1) Query() string {
+	time.Sleep(time.Millisecond)
+	return "ok"
+}
@@ -0,0 +1,31 @@
+package plugin2
+
+import (
+	"context"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2/tests/stop/plugin1"
+)
+
+type DB interface {
+	Query() string
+}
+
+// Plugin2 is the HTTP-like plugin, depends on the DB
+type Plugin2 struct {
+}
+
+func (p *Plugin2) Init(DB) error {
+	return nil
+}
+
+func (p *Plugin2) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin2) Stop(context.Context) error {
+	// slow shutdown, the DB should wait for it
+	time.Sleep(time.Millisecond * 200)
+	plugin1.Stopped("plugin2")
+	return nil
+}
@@ -0,0 +1,52 @@
+package stop
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/stop/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/stop/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/stop/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_StopOrder(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, &plugin2.Plugin2{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	report, err := c.StopWithReport()
+	require.NoError(t, err)
+
+	// dependent plugin should be stopped before its dependency
+	assert.Equal(t, []string{"plugin2", "plugin1"}, plugin1.Order())
+
+	require.Len(t, report, 2)
+	assert.Equal(t, "*plugin2.Plugin2", report[0].VertexID)
+	assert.GreaterOrEqual(t, report[0].Duration, time.Millisecond*200)
+	assert.NoError(t, report[0].Error)
+	assert.Equal(t, "*plugin1.Plugin1", report[1].VertexID)
+}
+
+func TestEndure_StopTimeout(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.GracefulShutdownTimeout(time.Millisecond*100))
+
+	require.NoError(t, c.RegisterAll(&plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	report, err := c.StopWithReport()
+	require.Error(t, err)
+	require.Len(t, report, 1)
+	assert.True(t, report[0].TimedOut)
+	assert.Error(t, report[0].Error)
+}
@@ -1,11 +1,13 @@
 package stress
 
 import (
+	stderr "errors"
 	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/graph"
 	"github.com/roadrunner-server/endure/v2/tests/stress/CyclicDeps"
 	"github.com/roadrunner-server/endure/v2/tests/stress/CyclicDepsCollects/p1"
 	"github.com/roadrunner-server/endure/v2/tests/stress/CyclicDepsCollects/p2"
@@ -15,6 +17,7 @@ import (
 	"github.com/roadrunner-server/endure/v2/tests/stress/ServeErr"
 	"github.com/roadrunner-server/endure/v2/tests/stress/mixed"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEndure_Init_Err(t *testing.T) {
@@ -108,3 +111,45 @@ func TestEndure_CyclicDepsInterfaceInit(t *testing.T) {
 
 	assert.Error(t, c.Init())
 }
+
+func TestEndure_CyclicDepsFail(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.OnCycle(endure.CycleFail))
+
+	assert.NoError(t, c.RegisterAll(
+		&CyclicDeps.Plugin1{},
+		&CyclicDeps.Plugin2{},
+		&CyclicDeps.Plugin3{},
+	))
+
+	err := c.Init()
+	require.Error(t, err)
+
+	var cerr *endure.CycleError
+	require.True(t, stderr.As(err, &cerr))
+	require.Len(t, cerr.Cycles, 1)
+	require.Len(t, cerr.Cycles[0], 3)
+
+	assert.Equal(t, "*CyclicDeps.Plugin1", cerr.Cycles[0][0].Plugin)
+	assert.Equal(t, "CyclicDeps.Stringer2", cerr.Cycles[0][0].Interface)
+	assert.Equal(t, graph.InitConnection, cerr.Cycles[0][0].Edge)
+	assert.Equal(t, "*CyclicDeps.Plugin2", cerr.Cycles[0][1].Plugin)
+	assert.Equal(t, "*CyclicDeps.Plugin3", cerr.Cycles[0][2].Plugin)
+}
+
+func TestEndure_CyclicDepsCollectsFail(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.OnCycle(endure.CycleFail))
+
+	assert.NoError(t, c.RegisterAll(
+		&p1.Plugin1{},
+		&p2.Plugin2{},
+	))
+
+	err := c.Init()
+	require.Error(t, err)
+
+	var cerr *endure.CycleError
+	require.True(t, stderr.As(err, &cerr))
+	require.Len(t, cerr.Cycles, 1)
+	require.Len(t, cerr.Cycles[0], 2)
+	assert.Equal(t, graph.CollectsConnection, cerr.Cycles[0][0].Edge)
+}
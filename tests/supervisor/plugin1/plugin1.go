@@ -0,0 +1,57 @@
+package plugin1
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+)
+
+// Plugin1 fails on the first Serve only
+type Plugin1 struct {
+	serves atomic.Int64
+	stops  atomic.Int64
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	errCh := make(chan error, 1)
+	if p.serves.Add(1) == 1 {
+		go func() {
+			time.Sleep(time.Millisecond * 50)
+			errCh <- errors.New("plugin1 failed")
+		}()
+	}
+
+	return errCh
+}
+
+func (p *Plugin1) Stop(context.Context) error {
+	p.stops.Add(1)
+	return nil
+}
+
+func (p *Plugin1) RestartPolicy() *endure.RestartPolicy {
+	return &endure.RestartPolicy{
+		Mode:              endure.RestartOnFailure,
+		InitialBackoff:    time.Millisecond * 10,
+		RestartDependents: true,
+	}
+}
+
+func (p *Plugin1) Hello() string {
+	return "hello"
+}
+
+func (p *Plugin1) Serves() int64 {
+	return p.serves.Load()
+}
+
+func (p *Plugin1) Stops() int64 {
+	return p.stops.Load()
+}
@@ -0,0 +1,36 @@
+package plugin2
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Plugin2 always fails
+type Plugin2 struct {
+	serves atomic.Int64
+}
+
+func (p *Plugin2) Init() error {
+	return nil
+}
+
+func (p *Plugin2) Serve() chan error {
+	p.serves.Add(1)
+	errCh := make(chan error, 1)
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		errCh <- errors.New("plugin2 failed")
+	}()
+
+	return errCh
+}
+
+func (p *Plugin2) Stop(context.Context) error {
+	return nil
+}
+
+func (p *Plugin2) Serves() int64 {
+	return p.serves.Load()
+}
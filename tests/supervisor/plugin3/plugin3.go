@@ -0,0 +1,38 @@
+package plugin3
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type Hello interface {
+	Hello() string
+}
+
+// Plugin3 depends on the Plugin1
+type Plugin3 struct {
+	serves atomic.Int64
+	stops  atomic.Int64
+}
+
+func (p *Plugin3) Init(Hello) error {
+	return nil
+}
+
+func (p *Plugin3) Serve() chan error {
+	p.serves.Add(1)
+	return make(chan error, 1)
+}
+
+func (p *Plugin3) Stop(context.Context) error {
+	p.stops.Add(1)
+	return nil
+}
+
+func (p *Plugin3) Serves() int64 {
+	return p.serves.Load()
+}
+
+func (p *Plugin3) Stops() int64 {
+	return p.stops.Load()
+}
@@ -0,0 +1,54 @@
+package plugin4
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+)
+
+type Hello interface {
+	Hello() string
+}
+
+// Plugin4 depends on the Plugin1 and closes its Serve channel on Stop
+type Plugin4 struct {
+	mu     sync.Mutex
+	errCh  chan error
+	serves atomic.Int64
+}
+
+func (p *Plugin4) Init(Hello) error {
+	return nil
+}
+
+func (p *Plugin4) Serve() chan error {
+	p.serves.Add(1)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errCh = make(chan error, 1)
+
+	return p.errCh
+}
+
+func (p *Plugin4) Stop(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	close(p.errCh)
+
+	return nil
+}
+
+func (p *Plugin4) RestartPolicy() *endure.RestartPolicy {
+	return &endure.RestartPolicy{
+		Mode:           endure.RestartAlways,
+		InitialBackoff: time.Millisecond * 10,
+	}
+}
+
+func (p *Plugin4) Serves() int64 {
+	return p.serves.Load()
+}
@@ -0,0 +1,153 @@
+package supervisor
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/supervisor/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/supervisor/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/supervisor/plugin3"
+	"github.com/roadrunner-server/endure/v2/tests/supervisor/plugin4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_RestartOnFailure(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	p3 := &plugin3.Plugin3{}
+	require.NoError(t, c.RegisterAll(p1, p3))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	select {
+	case r := <-res:
+		t.Fatalf("error should be handled by the supervisor, got: %v", r.Error)
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	assert.Equal(t, int64(2), p1.Serves())
+	assert.Equal(t, int64(1), p1.Stops())
+	// dependent plugin restarted as well
+	assert.Equal(t, int64(2), p3.Serves())
+	assert.Equal(t, int64(1), p3.Stops())
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_RestartClosesChannelOnStop(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	p4 := &plugin4.Plugin4{}
+	require.NoError(t, c.RegisterAll(p1, p4))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	select {
+	case r := <-res:
+		t.Fatalf("error should be handled by the supervisor, got: %v", r.Error)
+	case <-time.After(time.Millisecond * 500):
+	}
+
+	// channel closed by the Stop during the restart should not restart the plugin again
+	assert.Equal(t, int64(2), p1.Serves())
+	assert.Equal(t, int64(2), p4.Serves())
+
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_RestartLimit(t *testing.T) {
+	p2 := &plugin2.Plugin2{}
+	c := endure.New(slog.LevelDebug, endure.PluginRestartPolicy(p2, &endure.RestartPolicy{
+		Mode:           endure.RestartOnFailure,
+		InitialBackoff: time.Millisecond * 10,
+		MaxBackoff:     time.Millisecond * 20,
+		MaxRestarts:    2,
+		Window:         time.Minute,
+	}))
+
+	require.NoError(t, c.Register(p2))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	select {
+	case r := <-res:
+		assert.Error(t, r.Error)
+		assert.Equal(t, "*plugin2.Plugin2", r.VertexID)
+	case <-time.After(time.Second * 5):
+		t.Fatal("restarts limit should be exceeded")
+	}
+
+	// initial serve + 2 restarts
+	assert.Equal(t, int64(3), p2.Serves())
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_RestartPolicyInstances(t *testing.T) {
+	first := &plugin2.Plugin2{}
+	second := &plugin2.Plugin2{}
+	policy := func(restarts int) *endure.RestartPolicy {
+		return &endure.RestartPolicy{
+			Mode:           endure.RestartOnFailure,
+			InitialBackoff: time.Millisecond * 10,
+			MaxRestarts:    restarts,
+			Window:         time.Minute,
+		}
+	}
+
+	// the first instance is targeted by the value, the second one by the instance name
+	c := endure.New(slog.LevelDebug,
+		endure.PluginRestartPolicy(first, policy(1)),
+		endure.PluginRestartPolicy("second", policy(3)),
+	)
+
+	require.NoError(t, c.RegisterNamed("first", first))
+	require.NoError(t, c.RegisterNamed("second", second))
+	require.NoError(t, c.Init())
+
+	res, err := c.Serve()
+	require.NoError(t, err)
+
+	for range 2 {
+		select {
+		case r := <-res:
+			assert.Error(t, r.Error)
+		case <-time.After(time.Second * 5):
+			t.Fatal("restarts limit should be exceeded")
+		}
+	}
+
+	// initial serve + restarts
+	assert.Equal(t, int64(2), first.Serves())
+	assert.Equal(t, int64(4), second.Serves())
+	require.NoError(t, c.Stop())
+}
+
+func TestEndure_RestartPolicyZeroBackoff(t *testing.T) {
+	p2 := &plugin2.Plugin2{}
+	// no backoff and no restarts limit, the default backoff should prevent the restarts loop
+	c := endure.New(slog.LevelDebug, endure.PluginRestartPolicy(p2, &endure.RestartPolicy{Mode: endure.RestartOnFailure}))
+
+	require.NoError(t, c.Register(p2))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 500)
+
+	// 100ms, 200ms, 400ms... between the restarts
+	assert.GreaterOrEqual(t, p2.Serves(), int64(2))
+	assert.LessOrEqual(t, p2.Serves(), int64(4))
+	require.NoError(t, c.Stop())
+}
@@ -0,0 +1,55 @@
+package plugin1
+
+import (
+	"context"
+)
+
+type DB interface {
+	Query(q string) string
+}
+
+// FakeDB is the pre-built value, it implements the Service, but should not be served by endure
+type FakeDB struct {
+	DSN    string
+	served bool
+}
+
+func (f *FakeDB) Query(q string) string {
+	return f.DSN + ":" + q
+}
+
+func (f *FakeDB) Serve() chan error {
+	f.served = true
+	return make(chan error, 1)
+}
+
+func (f *FakeDB) Stop(context.Context) error {
+	return nil
+}
+
+func (f *FakeDB) Served() bool {
+	return f.served
+}
+
+// Plugin1 depends on the supplied DB
+type Plugin1 struct {
+	db DB
+}
+
+func (p *Plugin1) Init(db DB) error {
+	p.db = db
+	return nil
+}
+
+func (p *Plugin1) DB() DB {
+	return p.db
+}
+
+// PrimaryDB is the pre-built value with the higher weight
+type PrimaryDB struct {
+	FakeDB
+}
+
+func (p *PrimaryDB) Weight() uint {
+	return 10
+}
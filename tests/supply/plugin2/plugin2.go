@@ -0,0 +1,27 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/supply/plugin1"
+)
+
+// Plugin2 collects all databases
+type Plugin2 struct {
+	dbs []plugin1.DB
+}
+
+func (p *Plugin2) Init() error {
+	return nil
+}
+
+func (p *Plugin2) Collects() []*dep.In {
+	return []*dep.In{
+		dep.Fits(func(pp any) {
+			p.dbs = append(p.dbs, pp.(plugin1.DB))
+		}, (*plugin1.DB)(nil)),
+	}
+}
+
+func (p *Plugin2) DBs() []plugin1.DB {
+	return p.dbs
+}
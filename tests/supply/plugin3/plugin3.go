@@ -0,0 +1,26 @@
+package plugin3
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/supply/plugin1"
+)
+
+// Plugin3 depends on the replica DB
+type Plugin3 struct {
+	db plugin1.DB
+}
+
+func (p *Plugin3) Init(db plugin1.DB) error {
+	p.db = db
+	return nil
+}
+
+func (p *Plugin3) Qualifiers() []*dep.Qualifier {
+	return []*dep.Qualifier{
+		dep.Named((*plugin1.DB)(nil), "replica"),
+	}
+}
+
+func (p *Plugin3) DB() plugin1.DB {
+	return p.db
+}
@@ -0,0 +1,86 @@
+package supply
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/supply/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/supply/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/supply/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_Supply(t *testing.T) {
+	c := endure.New(slog.LevelDebug, endure.Visualize())
+
+	db := &plugin1.FakeDB{DSN: "test"}
+	p1 := &plugin1.Plugin1{}
+	p2 := &plugin2.Plugin2{}
+
+	require.NoError(t, c.Supply(db, (*plugin1.DB)(nil)))
+	require.NoError(t, c.RegisterAll(p1, p2))
+	require.NoError(t, c.Init())
+
+	require.NotNil(t, p1.DB())
+	assert.Equal(t, "test:select", p1.DB().Query("select"))
+	require.Len(t, p2.DBs(), 1)
+	assert.Same(t, db, p2.DBs()[0])
+	assert.Contains(t, c.Plugins(), "*plugin1.FakeDB")
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+	// supplied values are not served
+	assert.False(t, db.Served())
+
+	report, err := c.StopWithReport()
+	require.NoError(t, err)
+	assert.Empty(t, report)
+}
+
+func TestEndure_SupplyNamed(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	primary := &plugin1.FakeDB{DSN: "primary"}
+	replica := &plugin1.FakeDB{DSN: "replica"}
+	p2 := &plugin2.Plugin2{}
+	p3 := &plugin3.Plugin3{}
+
+	require.NoError(t, c.SupplyNamed("primary", primary, (*plugin1.DB)(nil)))
+	require.NoError(t, c.SupplyNamed("replica", replica, (*plugin1.DB)(nil)))
+	require.NoError(t, c.RegisterAll(p2, p3))
+	require.NoError(t, c.Init())
+
+	assert.Len(t, p2.DBs(), 2)
+	assert.Same(t, replica, p3.DB())
+	assert.Contains(t, c.Plugins(), "primary")
+	assert.Contains(t, c.Plugins(), "replica")
+
+	require.Error(t, c.SupplyNamed("", primary, (*plugin1.DB)(nil)))
+}
+
+func TestEndure_SupplyWeighted(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.Supply(&plugin1.FakeDB{DSN: "replica"}, (*plugin1.DB)(nil)))
+	require.NoError(t, c.Supply(&plugin1.PrimaryDB{FakeDB: plugin1.FakeDB{DSN: "primary"}}, (*plugin1.DB)(nil)))
+	require.NoError(t, c.Register(p1))
+	require.NoError(t, c.Init())
+
+	// Weighted supplied value is preferred
+	assert.Equal(t, "primary:select", p1.DB().Query("select"))
+}
+
+func TestEndure_SupplyInvalid(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	require.Error(t, c.Supply(nil))
+	// not consumable as its own type
+	require.Error(t, c.Supply(&plugin1.FakeDB{}))
+	// not an interface
+	require.Error(t, c.Supply(&plugin1.FakeDB{}, &plugin1.Plugin1{}))
+	// does not implement
+	require.Error(t, c.Supply(&plugin1.Plugin1{}, (*plugin1.DB)(nil)))
+}
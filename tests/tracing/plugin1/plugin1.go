@@ -0,0 +1,39 @@
+package plugin1
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Plugin1 provides the database and records the trace context passed to the Init and Stop
+type Plugin1 struct {
+	initSpan trace.SpanContext
+	stopSpan trace.SpanContext
+}
+
+func (p *Plugin1) Init(ctx context.Context) error {
+	p.initSpan = trace.SpanContextFromContext(ctx)
+	return nil
+}
+
+func (p *Plugin1) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin1) Stop(ctx context.Context) error {
+	p.stopSpan = trace.SpanContextFromContext(ctx)
+	return nil
+}
+
+func (p *Plugin1) Query() string {
+	return "plugin1"
+}
+
+func (p *Plugin1) InitSpan() trace.SpanContext {
+	return p.initSpan
+}
+
+func (p *Plugin1) StopSpan() trace.SpanContext {
+	return p.stopSpan
+}
@@ -0,0 +1,40 @@
+package plugin2
+
+import (
+	"context"
+
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type DB interface {
+	Query() string
+}
+
+// Plugin2 depends on the database and collects all databases
+type Plugin2 struct {
+	dbs []DB
+}
+
+func (p *Plugin2) Init(DB) error {
+	return nil
+}
+
+func (p *Plugin2) Serve() chan error {
+	return make(chan error, 1)
+}
+
+func (p *Plugin2) Stop(context.Context) error {
+	return nil
+}
+
+func (p *Plugin2) Weight() uint {
+	return 10
+}
+
+func (p *Plugin2) Collects() []*dep.In {
+	return []*dep.In{
+		dep.Fits(func(db any) {
+			p.dbs = append(p.dbs, db.(DB))
+		}, (*DB)(nil)),
+	}
+}
@@ -0,0 +1,22 @@
+package plugin3
+
+import (
+	"context"
+)
+
+// Plugin3 sends nil to the Serve channel
+type Plugin3 struct{}
+
+func (p *Plugin3) Init() error {
+	return nil
+}
+
+func (p *Plugin3) Serve() chan error {
+	errCh := make(chan error, 1)
+	errCh <- nil
+	return errCh
+}
+
+func (p *Plugin3) Stop(context.Context) error {
+	return nil
+}
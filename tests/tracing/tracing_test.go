@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/tracing/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/tracing/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/tracing/plugin3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndure_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c := endure.New(slog.LevelDebug, endure.Tracing(tp))
+
+	p1 := &plugin1.Plugin1{}
+	require.NoError(t, c.RegisterAll(p1, &plugin2.Plugin2{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.NoError(t, err)
+	require.NoError(t, c.Stop())
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+
+	for _, name := range []string{
+		"endure.init",
+		"endure.collects",
+		"endure.serve",
+		"endure.stop",
+		"*plugin1.Plugin1.Init",
+		"*plugin2.Plugin2.Init",
+		"*plugin2.Plugin2.Collects",
+		"*plugin1.Plugin1.Serve",
+		"*plugin1.Plugin1.Stop",
+	} {
+		require.Contains(t, spans, name)
+	}
+
+	// the calls are nested under their phases
+	assert.Equal(t, spans["endure.init"].SpanContext().SpanID(), spans["*plugin2.Plugin2.Init"].Parent().SpanID())
+	assert.Equal(t, spans["endure.collects"].SpanContext().SpanID(), spans["*plugin2.Plugin2.Collects"].Parent().SpanID())
+	assert.Equal(t, spans["endure.serve"].SpanContext().SpanID(), spans["*plugin1.Plugin1.Serve"].Parent().SpanID())
+	assert.Equal(t, spans["endure.stop"].SpanContext().SpanID(), spans["*plugin1.Plugin1.Stop"].Parent().SpanID())
+
+	attrs := attribute.NewSet(spans["*plugin2.Plugin2.Init"].Attributes()...)
+	id, _ := attrs.Value("endure.plugin.id")
+	assert.Equal(t, "*plugin2.Plugin2", id.AsString())
+	weight, _ := attrs.Value("endure.plugin.weight")
+	assert.Equal(t, int64(10), weight.AsInt64())
+	deps, _ := attrs.Value("endure.plugin.dependencies")
+	assert.Equal(t, []string{"plugin2.DB"}, deps.AsStringSlice())
+
+	attrs = attribute.NewSet(spans["*plugin2.Plugin2.Collects"].Attributes()...)
+	source, _ := attrs.Value("endure.collects.source")
+	assert.Equal(t, "*plugin1.Plugin1", source.AsString())
+
+	// trace context is passed to the context-aware Init and to the Stop
+	assert.Equal(t, spans["*plugin1.Plugin1.Init"].SpanContext().SpanID(), p1.InitSpan().SpanID())
+	assert.Equal(t, spans["*plugin1.Plugin1.Stop"].SpanContext().SpanID(), p1.StopSpan().SpanID())
+}
+
+func TestEndure_TracingNilServeError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c := endure.New(slog.LevelDebug, endure.Tracing(tp))
+
+	require.NoError(t, c.Register(&plugin3.Plugin3{}))
+	require.NoError(t, c.Init())
+
+	_, err := c.Serve()
+	require.Error(t, err)
+
+	var serve sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "*plugin3.Plugin3.Serve" {
+			serve = s
+		}
+	}
+
+	require.NotNil(t, serve)
+	assert.Equal(t, codes.Error, serve.Status().Code)
+}
@@ -0,0 +1,44 @@
+package plugin1
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+)
+
+type Storage interface {
+	Kind() string
+}
+
+type local struct{}
+
+func (l *local) Kind() string {
+	return "local"
+}
+
+type S3 struct{}
+
+func (s *S3) Kind() string {
+	return "s3"
+}
+
+// Plugin1 provides the storages via the typed API
+type Plugin1 struct {
+}
+
+func (p *Plugin1) Init() error {
+	return nil
+}
+
+func (p *Plugin1) Local() Storage {
+	return &local{}
+}
+
+func (p *Plugin1) S3() *S3 {
+	return &S3{}
+}
+
+func (p *Plugin1) Provides() []*dep.Out {
+	return []*dep.Out{
+		dep.Provide(p.Local),
+		dep.ProvideNamed("s3", p.S3),
+	}
+}
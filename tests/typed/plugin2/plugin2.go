@@ -0,0 +1,47 @@
+package plugin2
+
+import (
+	"github.com/roadrunner-server/endure/v2/dep"
+	"github.com/roadrunner-server/endure/v2/tests/typed/plugin1"
+)
+
+// Plugin2 collects the storages using both typed and untyped API
+type Plugin2 struct {
+	s3     plugin1.Storage
+	typed  []string
+	legacy []string
+}
+
+func (p *Plugin2) Init(s3 plugin1.Storage) error {
+	p.s3 = s3
+	return nil
+}
+
+func (p *Plugin2) Qualifiers() []*dep.Qualifier {
+	return []*dep.Qualifier{
+		dep.Named((*plugin1.Storage)(nil), "s3"),
+	}
+}
+
+func (p *Plugin2) Collects() []*dep.In {
+	return []*dep.In{
+		dep.Collect(func(s plugin1.Storage) {
+			p.typed = append(p.typed, s.Kind())
+		}),
+		dep.Fits(func(pp any) {
+			p.legacy = append(p.legacy, pp.(plugin1.Storage).Kind())
+		}, (*plugin1.Storage)(nil)),
+	}
+}
+
+func (p *Plugin2) S3() plugin1.Storage {
+	return p.s3
+}
+
+func (p *Plugin2) Typed() []string {
+	return p.typed
+}
+
+func (p *Plugin2) Legacy() []string {
+	return p.legacy
+}
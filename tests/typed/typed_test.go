@@ -0,0 +1,25 @@
+package typed
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/typed/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/typed/plugin2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_TypedProvideCollect(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p2 := &plugin2.Plugin2{}
+	require.NoError(t, c.RegisterAll(&plugin1.Plugin1{}, p2))
+	require.NoError(t, c.Init())
+
+	require.NotNil(t, p2.S3())
+	assert.Equal(t, "s3", p2.S3().Kind())
+	assert.ElementsMatch(t, []string{"local", "s3"}, p2.Typed())
+	assert.ElementsMatch(t, p2.Typed(), p2.Legacy())
+}
@@ -0,0 +1,21 @@
+package plugin1
+
+import (
+	"github.com/roadrunner-server/errors"
+)
+
+// Plugin1 disables itself
+type Plugin1 struct{}
+
+func (p *Plugin1) Init() error {
+	const op = errors.Op("plugin1_init")
+	return errors.E(op, errors.Disabled)
+}
+
+func (p *Plugin1) Name() string {
+	return "plugin1"
+}
+
+func (p *Plugin1) Query() string {
+	return "plugin1"
+}
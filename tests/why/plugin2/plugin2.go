@@ -0,0 +1,12 @@
+package plugin2
+
+type DB interface {
+	Query() string
+}
+
+// Plugin2 depends on the disabled plugin1
+type Plugin2 struct{}
+
+func (p *Plugin2) Init(DB) error {
+	return nil
+}
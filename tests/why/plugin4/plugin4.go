@@ -0,0 +1,16 @@
+package plugin4
+
+type B interface {
+	B() string
+}
+
+// Plugin4 and Plugin5 depend on each other
+type Plugin4 struct{}
+
+func (p *Plugin4) Init(B) error {
+	return nil
+}
+
+func (p *Plugin4) A() string {
+	return "a"
+}
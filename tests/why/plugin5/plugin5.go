@@ -0,0 +1,16 @@
+package plugin5
+
+type A interface {
+	A() string
+}
+
+// Plugin5 and Plugin4 depend on each other
+type Plugin5 struct{}
+
+func (p *Plugin5) Init(A) error {
+	return nil
+}
+
+func (p *Plugin5) B() string {
+	return "b"
+}
@@ -0,0 +1,12 @@
+package plugin6
+
+type B interface {
+	B() string
+}
+
+// Plugin6 depends on the plugin5 from the cycle
+type Plugin6 struct{}
+
+func (p *Plugin6) Init(B) error {
+	return nil
+}
@@ -0,0 +1,8 @@
+package plugin7
+
+// Plugin7 has no dependencies
+type Plugin7 struct{}
+
+func (p *Plugin7) Init() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package why
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/roadrunner-server/endure/v2"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin1"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin2"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin3"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin4"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin5"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin6"
+	"github.com/roadrunner-server/endure/v2/tests/why/plugin7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndure_Why(t *testing.T) {
+	c := endure.New(slog.LevelDebug)
+
+	p1 := &plugin1.Plugin1{}
+	p3 := &plugin3.Plugin3{}
+	p7 := &plugin7.Plugin7{}
+	require.NoError(t, c.RegisterAll(
+		p1,
+		&plugin2.Plugin2{},
+		p3,
+		&plugin4.Plugin4{},
+		&plugin5.Plugin5{},
+		&plugin6.Plugin6{},
+		p7,
+	))
+	require.NoError(t, c.Init())
+
+	reason := c.Why(p1)
+	require.NotNil(t, reason)
+	assert.Equal(t, endure.DisabledByInit, reason.Kind)
+	// Named plugin might be looked up by its name
+	assert.Equal(t, reason, c.Why("plugin1"))
+
+	reason = c.Why("*plugin2.Plugin2")
+	require.NotNil(t, reason)
+	assert.Equal(t, endure.DisabledCascade, reason.Kind)
+	assert.Equal(t, "*plugin1.Plugin1", reason.Root)
+
+	reason = c.Why(p3)
+	require.NotNil(t, reason)
+	assert.Equal(t, endure.DisabledMissingDependency, reason.Kind)
+	assert.Equal(t, []string{"plugin3.Cache"}, reason.Missing)
+	assert.Equal(t, "not enough Init dependencies, missing: plugin3.Cache", reason.String())
+
+	for _, id := range []string{"*plugin4.Plugin4", "*plugin5.Plugin5"} {
+		reason = c.Why(id)
+		require.NotNil(t, reason, id)
+		assert.Equal(t, endure.DisabledCycle, reason.Kind)
+		require.Len(t, reason.Cycle, 2)
+	}
+
+	reason = c.Why("*plugin6.Plugin6")
+	require.NotNil(t, reason)
+	assert.Equal(t, endure.DisabledCascade, reason.Kind)
+	assert.Equal(t, "*plugin5.Plugin5", reason.Root)
+
+	assert.Nil(t, c.Why(p7))
+	assert.Nil(t, c.Why("unknown"))
+
+	// reasons are included in the plugin states
+	states := c.PluginStates()
+	require.Len(t, states, 7)
+	for _, s := range states {
+		if s.Plugin == "*plugin7.Plugin7" {
+			assert.True(t, s.Active)
+			assert.Nil(t, s.Reason)
+			continue
+		}
+
+		assert.False(t, s.Active, s.Plugin)
+		assert.NotNil(t, s.Reason, s.Plugin)
+	}
+
+	assert.Equal(t, []string{"*plugin7.Plugin7"}, c.Plugins())
+}
@@ -0,0 +1,62 @@
+package endure
+
+import (
+	"context"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/roadrunner-server/endure/v2"
+
+	pluginIDKey     = attribute.Key("endure.plugin.id")
+	pluginWeightKey = attribute.Key("endure.plugin.weight")
+	pluginDepsKey   = attribute.Key("endure.plugin.dependencies")
+	collectedKey    = attribute.Key("endure.collects.type")
+	sourceKey       = attribute.Key("endure.collects.source")
+)
+
+// startPhase starts the parent span of the lifecycle phase (init, collects, serve, stop, restart, join)
+func (e *Endure) startPhase(ctx context.Context, name string) (context.Context, trace.Span) {
+	return e.tracer.Start(ctx, "endure."+name)
+}
+
+// startCall starts the span of the plugin's method call, e.g.: *plugin.Plugin.Init
+func (e *Endure) startCall(ctx context.Context, vertex *graph.Vertex, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		pluginIDKey.String(vertex.String()),
+		pluginWeightKey.Int64(int64(vertex.Weight())),
+		pluginDepsKey.StringSlice(dependencies(vertex)),
+	)
+
+	return e.tracer.Start(ctx, vertex.String()+"."+method, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the error (if any) and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// dependencies returns the Init dependency types of the vertex
+func dependencies(vertex *graph.Vertex) []string {
+	initMethod, ok := graph.InitMethod(vertex.Plugin())
+	if !ok {
+		return nil
+	}
+
+	args := graph.InitDeps(initMethod)
+	deps := make([]string, 0, len(args))
+	for j := range args {
+		deps = append(deps, args[j].String())
+	}
+
+	return deps
+}
@@ -1,5 +1,40 @@
 package endure
 
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/roadrunner-server/endure/v2/graph"
+	"github.com/roadrunner-server/errors"
+)
+
+// provide calls the provider (method with the receiver or the bound function), the context is injected when the provider accepts it
+// provider returns the value and optionally the error
+func provide(ctx context.Context, fn reflect.Value, in []reflect.Value) (reflect.Value, error) {
+	if fn.Type().NumIn() > len(in) && fn.Type().In(len(in)) == graph.ContextType {
+		in = append(slices.Clone(in), reflect.ValueOf(ctx))
+	}
+
+	if fn.Type().NumIn() != len(in) {
+		return reflect.Value{}, errors.Errorf("provider should not receive any arguments except the context.Context: %s", fn.Type().String())
+	}
+
+	vals := fn.Call(in)
+	switch len(vals) {
+	case 1:
+		return vals[0], nil
+	case 2:
+		if err, ok := vals[1].Interface().(error); ok && err != nil {
+			return reflect.Value{}, err
+		}
+
+		return vals[0], nil
+	default:
+		return reflect.Value{}, errors.Errorf("provider should return the value and optionally the error: %s", fn.Type().String())
+	}
+}
+
 // Handle all primitive (basic) types
 func isPrimitive(str string) bool {
 	switch str {
@@ -45,3 +80,18 @@ func isPrimitive(str string) bool {
 		return false
 	}
 }
+
+// isInterfaceSlice returns true for the slice of interfaces Init argument, e.g.: []Middleware
+func isInterfaceSlice(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Slice && tp.Elem().Kind() == reflect.Interface
+}
+
+// distinctPlugins returns the number of the distinct plugins implementing the dependency
+func distinctPlugins[T interface{ Plugin() any }](impl []T) int {
+	seen := make(map[any]struct{}, len(impl))
+	for i := range impl {
+		seen[impl[i].Plugin()] = struct{}{}
+	}
+
+	return len(seen)
+}
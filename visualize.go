@@ -64,3 +64,11 @@ func (e *Endure) Visualize(vertices []*graph.Vertex) error {
 
 	return nil
 }
+
+// DotGraph returns the dependency graph in the dot format, the graph is complete after the Init
+func (e *Endure) DotGraph() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.graph.DotString()
+}